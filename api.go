@@ -0,0 +1,276 @@
+package snake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIGamesPath is the path of the APIServer games collection.
+const APIGamesPath = "/games"
+
+// DefaultAPIBoardSize is the board width and height of the games
+// created without a size.
+const DefaultAPIBoardSize = 20
+
+// MinAPIBoardWidth, MinAPIBoardHeight and MaxAPIBoardSize bound the board
+// size of the games created through the APIServer.
+const (
+	MinAPIBoardWidth  = MinEngineWidth
+	MinAPIBoardHeight = MinEngineHeight
+	MaxAPIBoardSize   = 1000
+)
+
+// DefaultIdleTimeout is the time after which the APIServer
+// evicts a game which has not been requested.
+const DefaultIdleTimeout = 10 * time.Minute
+
+// APICreateRequest is the body of a game creation request.
+// Zero Width and Height default to DefaultAPIBoardSize, a nil Seed
+// defaults to the current time.
+type APICreateRequest struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Seed   *int64 `json:"seed"`
+}
+
+// APIStepRequest is the body of a game step request.
+// Direction is one of "Up", "Down", "Left" or "Right".
+type APIStepRequest struct {
+	Direction string `json:"direction"`
+}
+
+// APIGame is the body of the game responses. Face is the name
+// of the snake face direction, one of "Up", "Down", "Left" or "Right".
+type APIGame struct {
+	ID   string `json:"id"`
+	Seed int64  `json:"seed"`
+	Ate  bool   `json:"ate"`
+	Face string `json:"face"`
+	EngineState
+}
+
+// APIError is the body of the error responses.
+type APIError struct {
+	Error string `json:"error"`
+}
+
+// APIServer is an http.Handler which lets external agents play
+// many concurrent games over JSON requests:
+//
+//	POST   /games            creates a game, returning its ID
+//	GET    /games/{id}       returns the game state
+//	POST   /games/{id}/step  moves the snake once in the requested direction
+//	POST   /games/{id}/reset starts a new game with the same board size
+//	DELETE /games/{id}       deletes the game
+//
+// The games are played with an Engine, so the snake moves only when
+// stepped. Games which are not requested for the idle timeout are evicted.
+type APIServer struct {
+	idleTimeout time.Duration
+	clock       Clock
+	sessions    map[string]*apiSession
+	closed      bool
+	mu          sync.Mutex
+}
+
+// apiSession is a game played through the APIServer. Its idle timer
+// is guarded by the server mutex, its engine by the session mutex.
+type apiSession struct {
+	id     string
+	seed   int64
+	engine *Engine
+	timer  Timer
+	mu     sync.Mutex
+}
+
+// NewAPIServer returns a pointer to APIServer which evicts the games idle
+// for longer than idleTimeout, measured with clock. Close should be called
+// to stop the eviction timers.
+func NewAPIServer(idleTimeout time.Duration, clock Clock) *APIServer {
+	return &APIServer{idleTimeout: idleTimeout, clock: clock, sessions: make(map[string]*apiSession)}
+}
+
+// Close stops evicting the idle games.
+func (s *APIServer) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for _, session := range s.sessions {
+		session.timer.Stop()
+	}
+}
+
+// evict deletes session, unless it was deleted or replaced meanwhile.
+func (s *APIServer) evict(session *apiSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions[session.id] == session {
+		delete(s.sessions, session.id)
+	}
+}
+
+// Len returns the number of games.
+func (s *APIServer) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// ServeHTTP routes the games requests.
+func (s *APIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	if parts[0] != strings.Trim(APIGamesPath, "/") || len(parts) > 3 {
+		writeAPIError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if len(parts) == 1 {
+		if r.Method != http.MethodPost {
+			writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		s.create(w, r)
+		return
+	}
+	session := s.session(parts[1])
+	if session == nil {
+		writeAPIError(w, http.StatusNotFound, "game not found")
+		return
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	action := ""
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeAPIJSON(w, http.StatusOK, session.game(false))
+	case action == "" && r.Method == http.MethodDelete:
+		s.mu.Lock()
+		delete(s.sessions, session.id)
+		session.timer.Stop()
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case action == "step" && r.Method == http.MethodPost:
+		session.step(w, r)
+	case action == "reset" && r.Method == http.MethodPost:
+		if err := session.engine.Reset(); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeAPIJSON(w, http.StatusOK, session.game(false))
+	case action == "" || action == "step" || action == "reset":
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+	default:
+		writeAPIError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// create creates a game from the request body.
+func (s *APIServer) create(w http.ResponseWriter, r *http.Request) {
+	req := APICreateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		writeAPIError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if req.Width == 0 {
+		req.Width = DefaultAPIBoardSize
+	}
+	if req.Height == 0 {
+		req.Height = DefaultAPIBoardSize
+	}
+	if req.Width < MinAPIBoardWidth || req.Height < MinAPIBoardHeight ||
+		req.Width > MaxAPIBoardSize || req.Height > MaxAPIBoardSize {
+		writeAPIError(w, http.StatusBadRequest, "invalid board size")
+		return
+	}
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	engine, err := NewEngine(NewSnake(req.Width, req.Height), NewSeededFood(req.Width, req.Height, seed))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id, err := newSessionID()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	session := &apiSession{id: id, seed: seed, engine: engine}
+	s.mu.Lock()
+	session.timer = s.clock.AfterFunc(s.idleTimeout, func() { s.evict(session) })
+	if s.closed {
+		session.timer.Stop()
+	}
+	s.sessions[id] = session
+	s.mu.Unlock()
+	w.Header().Set("Location", APIGamesPath+"/"+id)
+	writeAPIJSON(w, http.StatusCreated, session.game(false))
+}
+
+// session returns the game with id, or nil if there is none,
+// restarting its idle timer.
+func (s *APIServer) session(id string) *apiSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session := s.sessions[id]
+	if session != nil && !s.closed {
+		session.timer.Reset(s.idleTimeout)
+	}
+	return session
+}
+
+// step moves the session snake in the request body direction.
+// It should be called with the session mutex locked.
+func (session *apiSession) step(w http.ResponseWriter, r *http.Request) {
+	var req APIStepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	d, ok := parseDirection(req.Direction)
+	if !ok {
+		writeAPIError(w, http.StatusBadRequest, "invalid direction: "+req.Direction)
+		return
+	}
+	ate, err := session.engine.Step(d)
+	if err == ErrGameOver {
+		writeAPIError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, session.game(ate))
+}
+
+// game returns the session response body.
+// It should be called with the session mutex locked.
+func (session *apiSession) game(ate bool) APIGame {
+	state := session.engine.State()
+	return APIGame{ID: session.id, Seed: session.seed, Ate: ate, Face: state.Face.String(), EngineState: state}
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeAPIJSON(w, status, APIError{message})
+}
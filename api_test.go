@@ -0,0 +1,189 @@
+package snake_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestAPIServer(t *testing.T) {
+	t.Run("should create a game", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+
+		got := createAPIGame(t, server, `{"width": 10, "height": 8, "seed": 42}`)
+
+		if got.ID == "" || got.Seed != 42 || got.Width != 10 || got.Height != 8 {
+			t.Errorf("got game %+v, want a 10x8 game with seed 42", got)
+		}
+		snake.AssertCoordinates(t, got.Snake, []snake.Coordinate{{X: 6, Y: 4}, {X: 7, Y: 4}, {X: 8, Y: 4}})
+	})
+
+	t.Run("should create games with the same food on the same seed", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+
+		first := createAPIGame(t, server, `{"seed": 7}`)
+		second := createAPIGame(t, server, `{"seed": 7}`)
+
+		if first.ID == second.ID {
+			t.Errorf("got the same ID %q for two games", first.ID)
+		}
+		snake.AssertCoordinate(t, second.Food, first.Food)
+		if first.Width != snake.DefaultAPIBoardSize || first.Height != snake.DefaultAPIBoardSize {
+			t.Errorf("got board size %dx%d, want the default size", first.Width, first.Height)
+		}
+	})
+
+	t.Run("should reject invalid board sizes", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+
+		resp := doAPIRequest(t, server, http.MethodPost, snake.APIGamesPath, `{"width": 3, "height": 3}`)
+
+		assertResponseStatus(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("should step, get, reset and delete a game", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+		game := createAPIGame(t, server, `{"width": 10, "height": 10, "seed": 1}`)
+		path := snake.APIGamesPath + "/" + game.ID
+
+		resp := doAPIRequest(t, server, http.MethodPost, path+"/step", `{"direction": "Up"}`)
+		assertResponseStatus(t, resp, http.StatusOK)
+		stepped := decodeAPIGame(t, resp)
+		snake.AssertCoordinate(t, stepped.Snake[0], snake.Coordinate{X: 6, Y: 4})
+
+		resp = doAPIRequest(t, server, http.MethodGet, path, "")
+		assertResponseStatus(t, resp, http.StatusOK)
+		if got := decodeAPIGame(t, resp); got.Steps != 1 || got.Face != "Up" {
+			t.Errorf("got game %+v, want one step up", got)
+		}
+
+		resp = doAPIRequest(t, server, http.MethodPost, path+"/reset", "")
+		assertResponseStatus(t, resp, http.StatusOK)
+		if got := decodeAPIGame(t, resp); got.Steps != 0 {
+			t.Errorf("got %d steps, want a new game", got.Steps)
+		}
+
+		resp = doAPIRequest(t, server, http.MethodDelete, path, "")
+		assertResponseStatus(t, resp, http.StatusNoContent)
+		resp = doAPIRequest(t, server, http.MethodGet, path, "")
+		assertResponseStatus(t, resp, http.StatusNotFound)
+	})
+
+	t.Run("should reject invalid directions and steps after game over", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+		game := createAPIGame(t, server, `{"width": 6, "height": 2}`)
+		path := snake.APIGamesPath + "/" + game.ID + "/step"
+
+		resp := doAPIRequest(t, server, http.MethodPost, path, `{"direction": "North"}`)
+		assertResponseStatus(t, resp, http.StatusBadRequest)
+
+		for i := 0; i < 4; i++ {
+			resp = doAPIRequest(t, server, http.MethodPost, path, `{"direction": "Left"}`)
+			assertResponseStatus(t, resp, http.StatusOK)
+		}
+		if got := decodeAPIGame(t, resp); !got.Over {
+			t.Fatalf("got game %+v, want a lost game", got)
+		}
+		resp = doAPIRequest(t, server, http.MethodPost, path, `{"direction": "Left"}`)
+		assertResponseStatus(t, resp, http.StatusConflict)
+	})
+
+	t.Run("should play concurrent games", func(t *testing.T) {
+		server := initAPIServer(t, snake.NewFakeClock(time.Now()))
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				game := createAPIGame(t, server, `{"width": 10, "height": 10}`)
+				for j := 0; j < 3; j++ {
+					resp := doAPIRequest(t, server, http.MethodPost, snake.APIGamesPath+"/"+game.ID+"/step", `{"direction": "Up"}`)
+					resp.Body.Close()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := server.Len(); got != 8 {
+			t.Errorf("got %d games, want 8", got)
+		}
+	})
+
+	t.Run("should evict idle games", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		server := initAPIServer(t, clock)
+		idle := createAPIGame(t, server, "")
+		used := createAPIGame(t, server, "")
+
+		clock.Advance(snake.DefaultIdleTimeout - time.Second)
+		resp := doAPIRequest(t, server, http.MethodGet, snake.APIGamesPath+"/"+used.ID, "")
+		assertResponseStatus(t, resp, http.StatusOK)
+		clock.Advance(time.Second)
+
+		resp = doAPIRequest(t, server, http.MethodGet, snake.APIGamesPath+"/"+idle.ID, "")
+		assertResponseStatus(t, resp, http.StatusNotFound)
+		resp = doAPIRequest(t, server, http.MethodGet, snake.APIGamesPath+"/"+used.ID, "")
+		assertResponseStatus(t, resp, http.StatusOK)
+		if got := server.Len(); got != 1 {
+			t.Errorf("got %d games, want 1", got)
+		}
+	})
+
+	t.Run("should not evict games after Close", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		server := initAPIServer(t, clock)
+		createAPIGame(t, server, "")
+
+		server.Close()
+		clock.Advance(snake.DefaultIdleTimeout)
+
+		if got := server.Len(); got != 1 {
+			t.Errorf("got %d games, want 1", got)
+		}
+	})
+}
+
+func initAPIServer(t testing.TB, clock snake.Clock) *snake.APIServer {
+	t.Helper()
+	server := snake.NewAPIServer(snake.DefaultIdleTimeout, clock)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func doAPIRequest(t testing.TB, server http.Handler, method, path, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, req)
+	return recorder.Result()
+}
+
+func createAPIGame(t testing.TB, server http.Handler, body string) snake.APIGame {
+	t.Helper()
+	resp := doAPIRequest(t, server, http.MethodPost, snake.APIGamesPath, body)
+	assertResponseStatus(t, resp, http.StatusCreated)
+	return decodeAPIGame(t, resp)
+}
+
+func decodeAPIGame(t testing.TB, resp *http.Response) snake.APIGame {
+	t.Helper()
+	defer resp.Body.Close()
+	var game snake.APIGame
+	if err := json.NewDecoder(resp.Body).Decode(&game); err != nil {
+		t.Fatal(err)
+	}
+	return game
+}
+
+func assertResponseStatus(t testing.TB, resp *http.Response, want int) {
+	t.Helper()
+	if resp.StatusCode != want {
+		t.Errorf("got status %d, want %d", resp.StatusCode, want)
+	}
+}
@@ -0,0 +1,71 @@
+package snake
+
+import "github.com/gdamore/tcell/v2"
+
+const ErrInvalidBounds = SnakeErr("snake: invalid bounds")
+
+// Wall rune and color, drawn on the board cells outside the arena.
+const (
+	WallRune  = '░'
+	WallColor = tcell.ColorSlateGray
+)
+
+// minArena is the width and height under which the arena does not shrink.
+const minArena = 5
+
+// Bounds are the board cells from Min to Max, included, inside which the
+// snakes move: the cells outside are walls. The zero Bounds, sent by the
+// games without walls, mean there are no walls.
+type Bounds struct {
+	Min Coordinate `json:"min"`
+	Max Coordinate `json:"max"`
+}
+
+// fullBounds returns the bounds of the whole width x height board.
+func fullBounds(width, height int) Bounds {
+	return Bounds{Coordinate{0, 0}, Coordinate{width - 1, height - 1}}
+}
+
+// Contains reports whether c is inside b.
+func (b Bounds) Contains(c Coordinate) bool {
+	return c.X >= b.Min.X && c.X <= b.Max.X && c.Y >= b.Min.Y && c.Y <= b.Max.Y
+}
+
+// containsAll reports whether all the coordinates c are inside b.
+func (b Bounds) containsAll(c []Coordinate) bool {
+	for _, coordinate := range c {
+		if !b.Contains(coordinate) {
+			return false
+		}
+	}
+	return true
+}
+
+// valid reports whether b is not empty and is inside a width x height board.
+func (b Bounds) valid(width, height int) bool {
+	return b.Min.X >= 0 && b.Min.Y >= 0 && b.Max.X < width && b.Max.Y < height &&
+		b.Min.X <= b.Max.X && b.Min.Y <= b.Max.Y
+}
+
+// shrunk returns b without its outer ring of cells, or b if the
+// shrunk bounds would be narrower or shorter than minArena.
+func (b Bounds) shrunk() Bounds {
+	s := Bounds{Coordinate{b.Min.X + 1, b.Min.Y + 1}, Coordinate{b.Max.X - 1, b.Max.Y - 1}}
+	if s.Max.X-s.Min.X+1 < minArena || s.Max.Y-s.Min.Y+1 < minArena {
+		return b
+	}
+	return s
+}
+
+// outside returns the cells of a width x height board outside b.
+func (b Bounds) outside(width, height int) []Coordinate {
+	var cells []Coordinate
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if c := (Coordinate{x, y}); !b.Contains(c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
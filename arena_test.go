@@ -0,0 +1,177 @@
+package snake_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestArena(t *testing.T) {
+	full := snake.Bounds{Min: snake.Coordinate{0, 0}, Max: snake.Coordinate{19, 9}}
+	shrunk := snake.Bounds{Min: snake.Coordinate{1, 1}, Max: snake.Coordinate{18, 8}}
+
+	// registerStrategy adds the strategy named name, which always moves
+	// towards d, to the strategies until the test ends.
+	registerStrategy := func(t *testing.T, name string, d snake.Direction) {
+		t.Helper()
+		snake.Strategies[name] = func(r *rand.Rand) snake.Strategy {
+			return snake.StrategyFunc(func(a snake.Arena) snake.Direction { return d })
+		}
+		t.Cleanup(func() { delete(snake.Strategies, name) })
+	}
+	// startGame starts a 20x10 game with the rules and the opponents, skipping
+	// the first opponents, arena, snake and food coordinates sent.
+	startGame := func(t *testing.T, rules snake.Rules, opponents []snake.Opponent, food ...snake.Coordinate) (*snake.Game, *snake.FakeClock) {
+		t.Helper()
+		fs := &snake.FoodStub{}
+		values := []snake.FoodStubValue{}
+		for _, c := range food {
+			values = append(values, snake.FoodStubValue{c, nil})
+		}
+		fs.Seed(values)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(20, 10), clock, fs)
+		t.Cleanup(g.Quit)
+		snake.AssertNoError(t, g.SetOpponents(opponents))
+		g.SetRules(rules)
+		g.Start(time.Microsecond)
+		if opponents != nil {
+			receiveOpponents(t, g)
+		}
+		assertArena(t, receiveArena(t, g), full)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		return g, clock
+	}
+
+	t.Run("snake should not move outside its bounds", func(t *testing.T) {
+		s := snake.NewSnake(20, 10)
+		snake.AssertError(t, s.SetBounds(snake.Bounds{Min: snake.Coordinate{5, 5}, Max: snake.Coordinate{20, 9}}), snake.ErrInvalidBounds)
+		snake.AssertNoError(t, s.SetBounds(snake.Bounds{Min: snake.Coordinate{0, 0}, Max: snake.Coordinate{19, 5}}))
+		snake.AssertError(t, s.Move(snake.Down), snake.ErrHeadOutOfBoard)
+		s.Reset()
+		if got := s.Bounds(); got != full {
+			t.Errorf("got bounds %+v after reset, want %+v", got, full)
+		}
+	})
+
+	t.Run("should shrink the arena moving the food inside", func(t *testing.T) {
+		g, clock := startGame(t, snake.Rules{Mode: "Battle royale", ShrinkEvery: 1}, nil, snake.Coordinate{0, 0}, snake.Coordinate{5, 5})
+
+		clock.Advance(time.Microsecond)
+		assertArena(t, receiveArena(t, g), shrunk)
+		sc, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+		assertSnakeLength(t, sc, 3)
+		_, _, f := snake.WaitAndReceiveGameChannels(t, g)
+		if f == nil || *f != (snake.Coordinate{5, 5}) {
+			t.Errorf("got food %v, want the food moved inside the arena on %v", f, snake.Coordinate{5, 5})
+		}
+	})
+
+	t.Run("should lose the game when the snake is caught outside the arena", func(t *testing.T) {
+		g, clock := startGame(t, snake.Rules{Mode: "Battle royale", ShrinkEvery: 5}, nil, snake.Coordinate{0, 5})
+
+		g.SendMove(snake.Up)
+		for i := 0; i < 4; i++ {
+			clock.Advance(time.Microsecond)
+			_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+		}
+		clock.Advance(time.Microsecond)
+		receiveArena(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, false)
+	})
+
+	t.Run("should kill the opponents caught outside the arena", func(t *testing.T) {
+		registerStrategy(t, "left", snake.Left)
+		opponents := []snake.Opponent{{Name: "Bot 1", Strategy: "left", Difficulty: snake.Hard}, {Name: "Bot 2", Strategy: "left", Difficulty: snake.Hard}}
+		g, clock := startGame(t, snake.Rules{Mode: "Battle royale", ShrinkEvery: 1}, opponents, snake.Coordinate{0, 0}, snake.Coordinate{5, 5})
+
+		clock.Advance(time.Microsecond)
+		receiveOpponents(t, g)
+		receiveArena(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		clock.Advance(time.Microsecond)
+		o := receiveOpponents(t, g)
+		if !o[0].Alive || o[1].Alive {
+			t.Errorf("got opponents %+v, want the one on the row 8 dead outside the arena", o)
+		}
+		receiveArena(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+	})
+
+	t.Run("should win the game when the snake is the last one alive", func(t *testing.T) {
+		registerStrategy(t, "up", snake.Up)
+		opponents := []snake.Opponent{{Name: "Bot", Strategy: "up", Difficulty: snake.Hard}}
+		g, clock := startGame(t, snake.Rules{Mode: "Battle royale", ShrinkEvery: 10, LastAlive: true}, opponents, snake.Coordinate{0, 0})
+
+		for i := 0; i < 2; i++ {
+			clock.Advance(time.Microsecond)
+			receiveOpponents(t, g)
+			_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+		}
+		clock.Advance(time.Microsecond)
+		receiveOpponents(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, true)
+	})
+
+	t.Run("should save and restore the arena", func(t *testing.T) {
+		g, clock := startGame(t, snake.Rules{Mode: "Battle royale", ShrinkEvery: 1}, nil, snake.Coordinate{5, 5})
+		clock.Advance(time.Microsecond)
+		receiveArena(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+
+		s := g.Save()
+		if s.Arena == nil || *s.Arena != shrunk {
+			t.Fatalf("got arena %v, want %+v", s.Arena, shrunk)
+		}
+		restored := snake.NewGame(snake.NewSnake(20, 10), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer restored.Quit()
+		snake.AssertNoError(t, restored.Restore(s))
+		if got := restored.Save().Arena; got == nil || *got != shrunk {
+			t.Errorf("got arena %v after restoring, want %+v", got, shrunk)
+		}
+
+		s.Food = snake.Coordinate{0, 0}
+		if err := s.Validate(); !errors.Is(err, snake.ErrInvalidSave) {
+			t.Errorf("got error %v validating the food outside the arena, want %v", err, snake.ErrInvalidSave)
+		}
+	})
+
+	t.Run("controller should set the arena on the view", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		<-view.ArenaC
+		game.SendArena(t, shrunk)
+		assertArena(t, <-view.ArenaC, shrunk)
+	})
+}
+
+func receiveArena(t testing.TB, g *snake.Game) snake.Bounds {
+	t.Helper()
+	return <-g.ReceiveArena()
+}
+
+func assertArena(t testing.TB, got, want snake.Bounds) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got arena %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+package snake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	ErrInvalidCampaign = CampaignError("snake: campaign: invalid campaign")
+	ErrLockedLevel     = CampaignError("snake: campaign: level is locked")
+)
+
+// CampaignMode is the game mode playing the campaign levels.
+const CampaignMode = "Campaign"
+
+// CampaignError type defines campaign errors
+type CampaignError string
+
+func (e CampaignError) Error() string {
+	return string(e)
+}
+
+// Level is a campaign level: a game on a Width x Height board, moving the
+// snake every Interval, with portals and entities, won when Goal is met.
+// A zero Width or Height keeps the board of the previous game,
+// a zero Interval keeps the speed of the previous game.
+type Level struct {
+	Name     string        `json:"name"`
+	Width    int           `json:"width,omitempty"`
+	Height   int           `json:"height,omitempty"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Goal     Goal          `json:"goal"`
+	Portals  []Portal      `json:"portals,omitempty"`
+	Entities []Entity      `json:"entities,omitempty"`
+}
+
+// validate returns ErrInvalidCampaign if a goal target, the board size or
+// the interval are negative, if the board is too small, or if the portals
+// and the entities do not fit the board off the snake. Levels without
+// a board size can not have portals nor entities.
+func (l Level) validate() error {
+	if !l.Goal.valid() || l.Width < 0 || l.Height < 0 || l.Interval < 0 {
+		return ErrInvalidCampaign
+	}
+	if l.Width == 0 || l.Height == 0 {
+		if len(l.Portals) > 0 || len(l.Entities) > 0 {
+			return ErrInvalidCampaign
+		}
+		return nil
+	}
+	if l.Width < MinEngineWidth || l.Height < MinEngineHeight {
+		return ErrInvalidCampaign
+	}
+	s := NewSnake(l.Width, l.Height)
+	if err := s.SetPortals(l.Portals); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCampaign, err)
+	}
+	if err := validateEntities(l.Entities, l.Width, l.Height, s.occupied()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCampaign, err)
+	}
+	return nil
+}
+
+// Campaign is a sequence of levels, played in order. The levels after
+// the first one are locked until the level before them is completed.
+type Campaign struct {
+	Levels   []Level `json:"levels"`
+	unlocked int
+	path     string
+}
+
+// DefaultCampaign returns the campaign played when no campaign file is given.
+func DefaultCampaign() *Campaign {
+	path := func(from Coordinate, d Direction, n int) []Coordinate {
+		c := []Coordinate{from}
+		for len(c) < n {
+			c = append(c, step(c[len(c)-1], d))
+		}
+		return c
+	}
+	return &Campaign{Levels: []Level{
+		{Name: "Warm up", Goal: Goal{Food: 5}},
+		{Name: "Growing up", Goal: Goal{Length: 12}, Interval: 150 * time.Millisecond},
+		{Name: "Patrol", Width: 40, Height: 20, Goal: Goal{Food: 8}, Entities: []Entity{
+			{Kind: Block, Position: Coordinate{8, 3}, Every: 2, Path: path(Coordinate{8, 3}, Down, 14)},
+			{Kind: Block, Position: Coordinate{31, 16}, Every: 2, Path: path(Coordinate{31, 16}, Up, 14)},
+		}},
+		{Name: "Mouse hunt", Width: 40, Height: 20, Goal: Goal{Food: 6}, Entities: []Entity{
+			{Kind: Mouse, Position: Coordinate{5, 5}, Every: 2},
+			{Kind: Mouse, Position: Coordinate{34, 14}, Every: 2},
+		}},
+		{Name: "Endurance", Width: 40, Height: 20, Goal: Goal{Survive: time.Minute}, Interval: 120 * time.Millisecond,
+			Portals: []Portal{{A: Coordinate{3, 3}, B: Coordinate{36, 16}}},
+			Entities: []Entity{
+				{Kind: Hazard, Position: Coordinate{10, 4}, Every: 3, Velocity: Coordinate{1, 1}},
+				{Kind: Hazard, Position: Coordinate{30, 15}, Every: 3, Velocity: Coordinate{-1, -1}},
+			}},
+	}}
+}
+
+// LoadCampaign reads the campaign levels from the JSON file at path.
+// It returns ErrInvalidCampaign if the file has no levels
+// or one of them is not valid.
+func LoadCampaign(path string) (*Campaign, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Campaign
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCampaign, err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate returns ErrInvalidCampaign if the campaign has no levels
+// or one of them is not valid or has no goal.
+func (c *Campaign) Validate() error {
+	if len(c.Levels) == 0 {
+		return ErrInvalidCampaign
+	}
+	for i, l := range c.Levels {
+		err := l.validate()
+		if err == nil && l.Goal == (Goal{}) {
+			err = ErrInvalidCampaign
+		}
+		if err != nil {
+			return fmt.Errorf("level %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// campaignProgress is the progress file content.
+type campaignProgress struct {
+	Unlocked int `json:"unlocked"`
+}
+
+// SetProgressFile loads the unlocked levels from the progress file at path,
+// if it exists, and saves the progress there on each Unlock. An empty path
+// keeps the progress in memory only.
+func (c *Campaign) SetProgressFile(path string) error {
+	c.path = path
+	if path == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var p campaignProgress
+	if err := json.Unmarshal(b, &p); err != nil {
+		return err
+	}
+	c.unlocked = p.Unlocked
+	return nil
+}
+
+// Unlocked returns the number of unlocked levels, which is at least one.
+func (c *Campaign) Unlocked() int {
+	if c.unlocked < 1 {
+		return 1
+	}
+	if c.unlocked > len(c.Levels) {
+		return len(c.Levels)
+	}
+	return c.unlocked
+}
+
+// Unlock unlocks the levels up to the i-th one, saving the progress
+// to the progress file, if set.
+func (c *Campaign) Unlock(i int) error {
+	if i+1 <= c.unlocked {
+		return nil
+	}
+	c.unlocked = i + 1
+	if c.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(campaignProgress{c.unlocked})
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path)
+}
+
+// Level returns the i-th level, or ErrLockedLevel if it is not unlocked.
+func (c *Campaign) Level(i int) (Level, error) {
+	if i < 0 || i >= c.Unlocked() {
+		return Level{}, ErrLockedLevel
+	}
+	return c.Levels[i], nil
+}
@@ -0,0 +1,250 @@
+package snake_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestCampaign(t *testing.T) {
+	newCampaign := func() *snake.Campaign {
+		return &snake.Campaign{Levels: []snake.Level{
+			{Name: "First", Goal: snake.Goal{Food: 1}},
+			{Name: "Second", Goal: snake.Goal{Length: 5}},
+		}}
+	}
+
+	t.Run("should describe the goals", func(t *testing.T) {
+		cases := map[snake.Goal]string{
+			{Food: 3}:                               "Eat 3 food",
+			{Length: 10, Survive: 30 * time.Second}: "Reach length 10, survive 30s",
+			{}:                                      "Fill the board",
+		}
+		for goal, want := range cases {
+			if got := goal.String(); got != want {
+				t.Errorf("got goal %q, want %q", got, want)
+			}
+		}
+	})
+
+	t.Run("should win the game when the goal is met", func(t *testing.T) {
+		cases := map[string]struct {
+			goal  snake.Goal
+			ticks int
+		}{
+			"food":    {snake.Goal{Food: 1}, 2},
+			"length":  {snake.Goal{Length: 4}, 2},
+			"survive": {snake.Goal{Survive: 3 * time.Microsecond}, 3},
+		}
+		for name, c := range cases {
+			fs := &snake.FoodStub{}
+			fs.Seed([]snake.FoodStubValue{{snake.Coordinate{34, 30}, nil}, {snake.Coordinate{0, 0}, nil}})
+			clock := snake.NewFakeClock(time.Now())
+			g := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+			g.SetWinCondition(c.goal)
+			g.Start(time.Microsecond)
+			snake.WaitAndReceiveGameChannels(t, g)
+			snake.WaitAndReceiveGameChannels(t, g)
+
+			var result *bool
+			for tick := 1; tick <= c.ticks; tick++ {
+				clock.Advance(time.Microsecond)
+				for result == nil {
+					sc, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+					if sc != nil && tick < c.ticks {
+						break
+					}
+					result = r
+				}
+			}
+			if result == nil || !*result {
+				t.Errorf("got result %v meeting the %s goal, want a win", result, name)
+			}
+			clock.Advance(time.Microsecond)
+			assertNoGameEvent(t, g)
+			g.Quit()
+		}
+	})
+
+	t.Run("should set the level on the game", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}, {snake.Coordinate{0, 1}, nil}})
+		g := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), fs)
+		defer g.Quit()
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		portals := []snake.Portal{{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 8, Y: 8}}}
+		mouse := snake.Entity{Kind: snake.Mouse, Position: snake.Coordinate{X: 2, Y: 8}}
+
+		err := g.SetLevel(snake.Level{Width: 10, Height: 10, Goal: snake.Goal{Food: 2}, Portals: portals, Entities: []snake.Entity{mouse}})
+		snake.AssertNoError(t, err)
+		if s := g.Save(); s.Width != 60 || len(s.Portals) != 0 || s.Goal != (snake.Goal{}) {
+			t.Errorf("got game in progress %+v, want it left as it is until the restart", s)
+		}
+		g.Restart(time.Microsecond)
+
+		assertEntities(t, receiveEntities(t, g), []snake.Entity{mouse})
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.AssertCoordinates(t, sc, snake.NewSnake(10, 10).GetCoordinates())
+		s := g.Save()
+		if s.Width != 10 || s.Height != 10 || s.Goal != (snake.Goal{Food: 2}) || len(s.Portals) != 1 {
+			t.Errorf("got saved game %+v, want the level board, goal and portals", s)
+		}
+	})
+
+	t.Run("should start on the level set before the start", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}})
+		g := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), fs)
+		defer g.Quit()
+		portals := []snake.Portal{{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 8, Y: 8}}}
+		snake.AssertNoError(t, g.SetLevel(snake.Level{Width: 10, Height: 10, Goal: snake.Goal{Food: 2}, Portals: portals}))
+		g.Start(time.Microsecond)
+
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.AssertCoordinates(t, sc, snake.NewSnake(10, 10).GetCoordinates())
+		if s := g.Save(); s.Width != 10 || s.Goal != (snake.Goal{Food: 2}) || len(s.Portals) != 1 {
+			t.Errorf("got started game %+v, want the level board, goal and portals", s)
+		}
+	})
+
+	t.Run("should not set invalid levels", func(t *testing.T) {
+		cases := map[string]snake.Level{
+			"negative goal":      {Goal: snake.Goal{Food: -1}},
+			"too small board":    {Width: 2, Height: 2, Goal: snake.Goal{Food: 1}},
+			"portals off board":  {Goal: snake.Goal{Food: 1}, Portals: []snake.Portal{{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 3, Y: 3}}}},
+			"entity on snake":    {Width: 10, Height: 10, Goal: snake.Goal{Food: 1}, Entities: []snake.Entity{{Kind: snake.Mouse, Position: snake.Coordinate{X: 6, Y: 5}}}},
+			"negative interval":  {Goal: snake.Goal{Food: 1}, Interval: -time.Second},
+			"portals on nothing": {Width: 10, Goal: snake.Goal{Food: 1}, Portals: []snake.Portal{{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 3, Y: 3}}}},
+		}
+		for name, l := range cases {
+			g := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+			if err := g.SetLevel(l); !errors.Is(err, snake.ErrInvalidCampaign) {
+				t.Errorf("got error %v setting level with %s, want %v", err, name, snake.ErrInvalidCampaign)
+			}
+		}
+	})
+
+	t.Run("should load campaign files", func(t *testing.T) {
+		dir := t.TempDir()
+		valid := filepath.Join(dir, "valid.json")
+		os.WriteFile(valid, []byte(`{"levels": [{"name": "One", "goal": {"food": 3}}, {"name": "Two", "width": 10, "height": 10, "goal": {"length": 6}}]}`), 0o644)
+
+		c, err := snake.LoadCampaign(valid)
+		snake.AssertNoError(t, err)
+		if len(c.Levels) != 2 || c.Levels[1].Goal.Length != 6 {
+			t.Errorf("got levels %+v, want the file levels", c.Levels)
+		}
+
+		files := map[string]string{
+			"no levels":  `{"levels": []}`,
+			"no goal":    `{"levels": [{"name": "One"}]}`,
+			"not json":   `levels`,
+			"bad entity": `{"levels": [{"name": "One", "width": 10, "height": 10, "goal": {"food": 1}, "entities": [{"kind": 9, "position": {"X": 1, "Y": 1}}]}]}`,
+		}
+		for name, content := range files {
+			path := filepath.Join(dir, "invalid.json")
+			os.WriteFile(path, []byte(content), 0o644)
+			if _, err := snake.LoadCampaign(path); !errors.Is(err, snake.ErrInvalidCampaign) {
+				t.Errorf("got error %v loading campaign with %s, want %v", err, name, snake.ErrInvalidCampaign)
+			}
+		}
+	})
+
+	t.Run("default campaign should be valid", func(t *testing.T) {
+		snake.AssertNoError(t, snake.DefaultCampaign().Validate())
+	})
+
+	t.Run("should unlock levels persistently", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "progress.json")
+		c := newCampaign()
+		err := c.SetProgressFile(path)
+		snake.AssertNoError(t, err)
+
+		if c.Unlocked() != 1 {
+			t.Errorf("got %d unlocked levels, want 1", c.Unlocked())
+		}
+		_, err = c.Level(1)
+		snake.AssertError(t, err, snake.ErrLockedLevel)
+		err = c.Unlock(1)
+		snake.AssertNoError(t, err)
+
+		reloaded := newCampaign()
+		err = reloaded.SetProgressFile(path)
+		snake.AssertNoError(t, err)
+		if reloaded.Unlocked() != 2 {
+			t.Errorf("got %d unlocked levels after reloading, want 2", reloaded.Unlocked())
+		}
+		l, err := reloaded.Level(1)
+		snake.AssertNoError(t, err)
+		if l.Name != "Second" {
+			t.Errorf("got level %q, want %q", l.Name, "Second")
+		}
+	})
+
+	t.Run("controller should advance to the next level carrying the score", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		campaign := newCampaign()
+		controller.SetCampaign(campaign)
+
+		go controller.Start(time.Microsecond)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), snake.ModeSelectState.String(), snake.DefaultMode)
+		for range snake.Modes {
+			view.SendDirection(t, snake.Down)
+			view.GetMenu(t)
+		}
+		view.SendSelect(t)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), snake.LevelSelectState.String(), "1. First")
+		view.SendSelect(t)
+		intro := view.GetMenu(t)
+		assertMenu(t, intro, "Level 1: First", snake.StartItem)
+		if intro.Text[0] != "Eat 1 food." {
+			t.Errorf("got intro text %q, want the level goal", intro.Text)
+		}
+		view.SendSelect(t)
+		<-game.StartC
+
+		game.SendFoodCoordinate(t, snake.Coordinate{5, 5})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		status := view.GetStatus(t)
+		if status.Mode != "Campaign 1/2" {
+			t.Errorf("got mode %q, want %q", status.Mode, "Campaign 1/2")
+		}
+		game.SendScore(t, 1)
+		game.SendResult(t, true)
+
+		intro = view.GetMenu(t)
+		assertMenu(t, intro, "Level 2: Second", snake.StartItem)
+		if intro.Text[1] != "Score: 1" {
+			t.Errorf("got intro text %q, want the carried score", intro.Text)
+		}
+		if campaign.Unlocked() != 2 {
+			t.Errorf("got %d unlocked levels, want 2", campaign.Unlocked())
+		}
+		view.SendSelect(t)
+		<-game.RestartC
+		game.SendSnakeCoordinates(t, []snake.Coordinate{{0, 0}})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		assertStatus(t, view.GetStatus(t), 1, 1)
+		game.SendScore(t, 1)
+		game.SendSnakeCoordinates(t, []snake.Coordinate{{0, 0}, {1, 0}})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		assertStatus(t, view.GetStatus(t), 2, 2)
+	})
+}
@@ -0,0 +1,240 @@
+package snake
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the interface that wraps the time functions used by the game,
+// so that the game time can be controlled.
+//
+// Now returns the current time.
+//
+// NewTicker returns a Ticker which ticks every d time.Duration.
+// It panics if d is not positive.
+//
+// AfterFunc returns a Timer which calls f in its own go routine
+// after d time.Duration.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Ticker is the interface that wraps a time.Ticker.
+//
+// C returns the channel on which the ticks are delivered. Like a
+// time.Ticker, the ticks are dropped if the receiver does not keep up.
+//
+// Reset stops the ticker and resets it to tick every d time.Duration.
+//
+// Stop stops the ticker. No more ticks are delivered until Reset.
+type Ticker interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// Timer is the interface that wraps a time.Timer created by time.AfterFunc.
+//
+// Reset changes the timer to expire after d time.Duration. It returns true
+// if the timer had been active.
+//
+// Stop prevents the timer from firing. It returns true if the timer
+// had been active.
+type Timer interface {
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// RealClock is a Clock which wraps the time package.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns a Ticker wrapping time.NewTicker(d).
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// AfterFunc returns time.AfterFunc(d, f).
+func (RealClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t realTicker) Reset(d time.Duration) {
+	t.ticker.Reset(d)
+}
+
+func (t realTicker) Stop() {
+	t.ticker.Stop()
+}
+
+// FakeClock is a Clock whose time only moves when Advance is called.
+// It is safe for concurrent use.
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+	mu     sync.Mutex
+}
+
+// fakeTimer is a FakeClock ticker, delivering the ticks on c every period,
+// or a timer, calling f once.
+type fakeTimer struct {
+	clock  *FakeClock
+	when   time.Time
+	period time.Duration
+	c      chan time.Time
+	f      func()
+	active bool
+}
+
+// NewFakeClock returns a pointer to FakeClock, starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a Ticker which ticks each time the clock
+// is advanced by d time.Duration. It panics if d is not positive.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("snake: fake clock: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), period: d, c: make(chan time.Time, 1), active: true}
+	c.timers = append(c.timers, t)
+	return fakeTicker{t}
+}
+
+// AfterFunc returns a Timer which calls f when the clock
+// is advanced by d time.Duration.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, when: c.now.Add(d), f: f, active: true}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d time.Duration, firing the tickers and
+// timers which expire meanwhile in expiration order. Unlike the time package
+// timers, the timer functions are called on the go routine calling Advance,
+// which returns after they return.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for {
+		t := c.next(end)
+		if t == nil {
+			break
+		}
+		c.now = t.when
+		if t.f != nil {
+			t.active = false
+			c.mu.Unlock()
+			t.f()
+			c.mu.Lock()
+			continue
+		}
+		select {
+		case t.c <- c.now:
+		default:
+		}
+		t.when = t.when.Add(t.period)
+	}
+	c.now = end
+	c.timers = c.activeTimers()
+	c.mu.Unlock()
+}
+
+// next returns the active timer which expires first, not after end,
+// or nil if there is none. It should be called with the clock mutex locked.
+func (c *FakeClock) next(end time.Time) *fakeTimer {
+	var next *fakeTimer
+	for _, t := range c.timers {
+		if t.active && !t.when.After(end) && (next == nil || t.when.Before(next.when)) {
+			next = t
+		}
+	}
+	return next
+}
+
+// activeTimers returns the active timers.
+// It should be called with the clock mutex locked.
+func (c *FakeClock) activeTimers() []*fakeTimer {
+	active := c.timers[:0]
+	for _, t := range c.timers {
+		if t.active {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// Reset resets a ticker to tick every d time.Duration, or a timer
+// to expire after d time.Duration, returning true if it had been active.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	if t.f == nil {
+		t.period = d
+	}
+	t.when = t.clock.now.Add(d)
+	t.active = true
+	for _, timer := range t.clock.timers {
+		if timer == t {
+			return active
+		}
+	}
+	t.clock.timers = append(t.clock.timers, t)
+	return active
+}
+
+// Stop stops the ticker or the timer, returning true if it had been active.
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+// fakeTicker adapts a ticking fakeTimer to the Ticker interface.
+type fakeTicker struct {
+	*fakeTimer
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("snake: fake clock: non-positive interval for Ticker.Reset")
+	}
+	t.fakeTimer.Reset(d)
+}
+
+func (t fakeTicker) Stop() {
+	t.fakeTimer.Stop()
+}
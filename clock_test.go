@@ -0,0 +1,127 @@
+package snake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should move the time only on Advance", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+
+		if got := clock.Now(); !got.Equal(start) {
+			t.Errorf("got time %v, want %v", got, start)
+		}
+		clock.Advance(time.Minute)
+		if got, want := clock.Now(), start.Add(time.Minute); !got.Equal(want) {
+			t.Errorf("got time %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should tick on each interval", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+
+		clock.Advance(time.Second - 1)
+		assertNoTick(t, ticker)
+		clock.Advance(1)
+		assertTick(t, ticker, start.Add(time.Second))
+		clock.Advance(time.Second)
+		assertTick(t, ticker, start.Add(2*time.Second))
+	})
+
+	t.Run("should drop the ticks not received", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+
+		clock.Advance(3 * time.Second)
+		assertTick(t, ticker, start.Add(time.Second))
+		assertNoTick(t, ticker)
+	})
+
+	t.Run("should stop and reset a ticker", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+		ticker := clock.NewTicker(time.Second)
+
+		ticker.Stop()
+		clock.Advance(time.Second)
+		assertNoTick(t, ticker)
+		ticker.Reset(time.Minute)
+		clock.Advance(time.Second)
+		assertNoTick(t, ticker)
+		clock.Advance(time.Minute)
+		assertTick(t, ticker, start.Add(time.Minute+time.Second))
+	})
+
+	t.Run("should call the timer functions in order", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+		var got []int
+		clock.AfterFunc(2*time.Second, func() { got = append(got, 2) })
+		clock.AfterFunc(time.Second, func() {
+			got = append(got, 1)
+			clock.AfterFunc(2*time.Second, func() { got = append(got, 3) })
+		})
+		stopped := clock.AfterFunc(time.Second, func() { got = append(got, 0) })
+
+		if !stopped.Stop() {
+			t.Error("should have stopped an active timer")
+		}
+		clock.Advance(3 * time.Second)
+
+		want := []int{1, 2, 3}
+		if len(got) != len(want) || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+			t.Errorf("got timers %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should reset a timer", func(t *testing.T) {
+		clock := snake.NewFakeClock(start)
+		fired := 0
+		timer := clock.AfterFunc(time.Second, func() { fired++ })
+
+		clock.Advance(time.Second / 2)
+		if !timer.Reset(time.Second) {
+			t.Error("should have reset an active timer")
+		}
+		clock.Advance(time.Second / 2)
+		if fired != 0 {
+			t.Fatal("should not have fired the reset timer")
+		}
+		clock.Advance(time.Second / 2)
+		if fired != 1 {
+			t.Fatalf("got %d timer calls, want 1", fired)
+		}
+		if timer.Reset(time.Second) {
+			t.Error("should not have reset an expired timer as active")
+		}
+		clock.Advance(time.Second)
+		if fired != 2 {
+			t.Errorf("got %d timer calls, want 2", fired)
+		}
+	})
+}
+
+func assertTick(t testing.TB, ticker snake.Ticker, want time.Time) {
+	t.Helper()
+	select {
+	case got := <-ticker.C():
+		if !got.Equal(want) {
+			t.Errorf("got tick at %v, want %v", got, want)
+		}
+	default:
+		t.Error("should have ticked")
+	}
+}
+
+func assertNoTick(t testing.TB, ticker snake.Ticker) {
+	t.Helper()
+	select {
+	case got := <-ticker.C():
+		t.Errorf("got tick at %v, want none", got)
+	default:
+	}
+}
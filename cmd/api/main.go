@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8081", "address on which the server listens")
+	idleTimeout := flag.Duration("idle-timeout", snake.DefaultIdleTimeout, "time after which idle games are deleted")
+	flag.Parse()
+
+	server := snake.NewAPIServer(*idleTimeout, snake.RealClock{})
+	defer server.Close()
+
+	log.Printf("Serving the games API on http://%s%s", *addr, snake.APIGamesPath)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
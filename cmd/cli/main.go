@@ -1,14 +1,91 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/castagnadaniele/go-snake"
+	"github.com/castagnadaniele/go-snake/internal/supervisor"
 	"github.com/gdamore/tcell/v2"
 )
 
+// interval is the snake move interval of the first game.
+const interval = time.Millisecond * 200
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "tournament" {
+		if err := runTournament(os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	halfBlock := flag.Bool("halfblock", false, "draw square cells packing two board rows in a terminal row")
+	boardWidth := flag.Int("width", 0, "board width, defaults to the terminal width")
+	boardHeight := flag.Int("height", 0, "board height, defaults to the terminal height")
+	deadZone := flag.Int("deadzone", snake.DefaultDeadZone, "cells between the snake head and the viewport sides before scrolling")
+	resizeBoard := flag.Bool("resize-board", false, "resize the board to fit the terminal on the next game after a terminal resize")
+	saveFile := flag.String("save", defaultConfigFile("save.json"), "file on which the game is saved when pressing S or quitting, disabled if empty")
+	resume := flag.Bool("resume", false, "resume the game saved on the save file")
+	portals := flag.Int("portals", 0, "number of portal pairs placed at random on the board")
+	blocks := flag.Int("blocks", 0, "number of blocks patrolling the board")
+	mice := flag.Int("mice", 0, "number of mice fleeing the snake, which the snake eats like the food")
+	hazards := flag.Int("hazards", 0, "number of hazards bouncing around the board")
+	opponents := flag.Int("opponents", 0, "number of computer snakes competing for the food")
+	strategy := flag.String("strategy", "cautious", "strategy of the computer snakes: random, greedy or cautious")
+	difficulty := flag.String("difficulty", string(snake.Normal), "difficulty of the computer snakes: easy, normal or hard")
+	campaignFile := flag.String("campaign", "", "JSON file with the campaign levels, defaults to the built-in campaign")
+	mode := flag.String("mode", snake.DefaultMode, "game mode: classic, time-attack, survival, zen, battle-royale or campaign")
+	lives := flag.Int("lives", 0, "number of lives, the snake respawns when it dies until the lives are over, defaults to one life")
+	cutTail := flag.Bool("cut-tail", false, "bite off the tail instead of losing when the snake hits its body")
+	win := flag.String("win", "", "targets winning the game, like food=5,length=12 to meet all of them or score=20|survive=2m to meet any, defaults to filling the board")
+	progressFile := flag.String("progress", defaultConfigFile("progress.json"), "file on which the unlocked campaign levels are saved, disabled if empty")
+	flag.Parse()
+
+	var saved *snake.SaveState
+	if *resume {
+		s, err := snake.LoadFile(*saveFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		saved = &s
+	}
+	for _, path := range []string{*saveFile, *progressFile} {
+		if path == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+	}
+	goal, err := snake.ParseGoal(*win)
+	if err != nil {
+		log.Fatal(err)
+	}
+	campaign := snake.DefaultCampaign()
+	if *campaignFile != "" {
+		c, err := snake.LoadCampaign(*campaignFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		campaign = c
+	}
+	if err := campaign.SetProgressFile(*progressFile); err != nil {
+		log.Fatal(err)
+	}
+
 	screen, err := tcell.NewScreen()
 	if err != nil {
 		log.Fatal(err)
@@ -17,18 +94,222 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	width, height := screen.Size()
+	view := snake.NewView(screen)
+	defer view.Release()
+	if *halfBlock {
+		view.SetRenderer(snake.HalfBlockRenderer{})
+	}
+	view.SetDeadZone(*deadZone)
+	width, height := view.BoardSize()
+	if *boardWidth > 0 {
+		width = *boardWidth
+	}
+	if *boardHeight > 0 {
+		height = *boardHeight
+	}
+	if saved != nil {
+		width, height = saved.Width, saved.Height
+	}
+	view.SetBoardSize(width, height)
 
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	s := snake.NewSnake(width, height)
+	if *portals > 0 {
+		p, err := snake.RandomPortals(*portals, width, height, s.GetCoordinates(), r)
+		if err == nil {
+			err = s.SetPortals(p)
+		}
+		if err != nil {
+			view.Release()
+			log.Fatal(err)
+		}
+		view.SetPortals(p)
+	}
+	if saved != nil {
+		view.SetPortals(saved.Portals)
+	}
 	food := snake.NewFood(width, height)
-	cloak := snake.NewCloak()
-	defer cloak.Stop()
-	game := snake.NewGame(s, cloak, food)
-	view := snake.NewView(screen)
-	defer view.Release()
+	game := snake.NewGame(s, snake.RealClock{}, food)
+	if saved == nil {
+		entities, err := randomEntities(r, width, height, s, map[snake.EntityKind]int{snake.Block: *blocks, snake.Mouse: *mice, snake.Hazard: *hazards})
+		if err == nil {
+			err = game.SetEntities(entities)
+		}
+		if err == nil {
+			err = game.SetOpponents(newOpponents(r, *opponents, *strategy, snake.Difficulty(*difficulty)))
+		}
+		if err != nil {
+			view.Release()
+			log.Fatal(err)
+		}
+	}
 	controller := snake.NewController(game, view)
+	controller.SetResizeBoard(*resizeBoard)
+	controller.SetSaveFile(*saveFile)
+	controller.SetCampaign(campaign)
+	controller.SetWinCondition(goal)
+	controller.SetTailCutting(*cutTail)
+	controller.SetLives(*lives)
+	if err := controller.SetMode(*mode); err != nil {
+		view.Release()
+		log.Fatalf("%v: %s", err, *mode)
+	}
+	if saved != nil {
+		if err := controller.Restore(*saved); err != nil {
+			view.Release()
+			log.Fatal(err)
+		}
+	}
+
+	// the terminal is in raw mode, so CTRL+C is a key press: the signals
+	// come from kill or from a closed terminal
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+	group, ctx := supervisor.WithContext(ctx)
+	group.Go(func() error {
+		return controller.Run(ctx, interval)
+	})
+
+	err = group.Wait()
+	if err == nil || errors.Is(err, context.Canceled) {
+		err = controller.Err()
+	}
+	if err != nil {
+		view.Release()
+		log.Fatal(err)
+	}
+}
+
+// randomEntities places at random with r the number of entities of each kind
+// on a width x height board, off the snake and its portals.
+func randomEntities(r *rand.Rand, width, height int, s *snake.Snake, counts map[snake.EntityKind]int) ([]snake.Entity, error) {
+	occupied := append([]snake.Coordinate{}, s.GetCoordinates()...)
+	for _, p := range s.Portals() {
+		occupied = append(occupied, p.A, p.B)
+	}
+	var entities []snake.Entity
+	for _, kind := range []snake.EntityKind{snake.Block, snake.Mouse, snake.Hazard} {
+		e, err := snake.RandomEntities(kind, counts[kind], width, height, occupied, r)
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range e {
+			occupied = append(append(occupied, entity.Position), entity.Path...)
+		}
+		entities = append(entities, e...)
+	}
+	return entities, nil
+}
+
+// newOpponents returns n opponents playing strategy with difficulty,
+// seeded at random with r.
+func newOpponents(r *rand.Rand, n int, strategy string, difficulty snake.Difficulty) []snake.Opponent {
+	var opponents []snake.Opponent
+	for i := 1; i <= n; i++ {
+		opponents = append(opponents, snake.Opponent{Name: fmt.Sprintf("Bot %d", i), Strategy: strategy, Difficulty: difficulty, Seed: r.Int63()})
+	}
+	return opponents
+}
+
+// defaultConfigFile returns the path of the name file in the user
+// configuration directory, or an empty path if there is none.
+func defaultConfigFile(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "go-snake", name)
+}
+
+// runTournament plays the bot tournament configured by the args of the
+// tournament command, printing the standings table on w and writing the
+// replays of each match on the replays directory, if any.
+func runTournament(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("tournament", flag.ExitOnError)
+	bots := flags.String("bots", "random,greedy,cautious", "comma separated bot strategies, each with an optional difficulty like greedy:hard")
+	format := flags.String("format", snake.RoundRobin, "tournament format: round-robin or swiss")
+	rounds := flags.Int("rounds", 0, "rounds of a swiss tournament, defaults to the base 2 logarithm of the number of bots")
+	width := flags.Int("width", 30, "board width")
+	height := flags.Int("height", 20, "board height")
+	shrinkEvery := flags.Int("shrink-every", snake.BattleRoyaleRules.ShrinkEvery, "ticks after which the arena shrinks, never if 0")
+	ticks := flags.Int("ticks", snake.DefaultMatchTicks, "ticks after which an undecided game is won by the higher score")
+	seed := flags.Int64("seed", 1, "seed of the food and of the bots random moves")
+	workers := flags.Int("workers", 0, "games played concurrently, defaults to the number of CPUs")
+	replays := flags.String("replays", "", "directory on which the match replays are written, disabled if empty")
+	flags.Parse(args)
+
+	opponents, err := parseBots(*bots)
+	if err != nil {
+		return err
+	}
+	t := snake.NewTournament(opponents, *format, *seed)
+	if *rounds > 0 {
+		t.Rounds = *rounds
+	}
+	if *workers > 0 {
+		t.Workers = *workers
+	}
+	t.Width, t.Height, t.Ticks = *width, *height, *ticks
+	t.Rules.ShrinkEvery = *shrinkEvery
+	standings, results, err := t.Play()
+	if err != nil {
+		return err
+	}
+	if *replays != "" {
+		if err := writeReplays(*replays, results); err != nil {
+			return err
+		}
+	}
+	return writeStandings(w, standings)
+}
 
-	go controller.Start(time.Millisecond * 200)
+// parseBots returns the bots of the comma separated strategies,
+// named after them, each with an optional difficulty after a colon.
+func parseBots(s string) ([]snake.Opponent, error) {
+	var bots []snake.Opponent
+	for _, spec := range strings.Split(s, ",") {
+		spec = strings.TrimSpace(spec)
+		strategy := strings.SplitN(spec, ":", 2)
+		bot := snake.Opponent{Name: spec, Strategy: strategy[0]}
+		if len(strategy) == 2 {
+			bot.Difficulty = snake.Difficulty(strategy[1])
+		}
+		if _, ok := snake.Strategies[bot.Strategy]; !ok {
+			return nil, fmt.Errorf("%w: %s", snake.ErrUnknownStrategy, bot.Strategy)
+		}
+		bots = append(bots, bot)
+	}
+	return bots, nil
+}
 
-	<-controller.WaitForQuitSignal()
+// writeStandings writes the standings table on w.
+func writeStandings(w io.Writer, standings []snake.Standing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Rank\tBot\tRating\tPlayed\tWon\tDrawn\tLost\tByes\tPoints\t")
+	for i, s := range standings {
+		fmt.Fprintf(tw, "%d\t%s\t%.0f\t%d\t%d\t%d\t%d\t%d\t%.1f\t\n", i+1, s.Bot.Name, s.Rating, s.Played, s.Won, s.Drawn, s.Lost, s.Byes, s.Points)
+	}
+	return tw.Flush()
+}
+
+// writeReplays writes each match result, with the replays of its games,
+// on a JSON file of dir named after the match round and number.
+func writeReplays(dir string, results []snake.MatchResult) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for i, r := range results {
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("round-%02d-match-%03d.json", r.Round, i+1)))
+		if err != nil {
+			return err
+		}
+		if err := json.NewEncoder(f).Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address on which the server listens")
+	width := flag.Int("width", 40, "board width")
+	height := flag.Int("height", 30, "board height")
+	watchAddr := flag.String("watch-addr", "", "address on which spectators can watch the game, disabled if empty")
+	mode := flag.String("mode", snake.DefaultMode, "game mode: classic, time-attack, survival, zen or battle-royale")
+	opponents := flag.Int("opponents", 0, "number of computer snakes competing for the food")
+	strategy := flag.String("strategy", "cautious", "strategy of the computer snakes: random, greedy or cautious")
+	allowOrigin := flag.String("allow-origin", "", "comma separated origins, besides the server one, whose pages can play the game")
+	flag.Parse()
+
+	view := snake.NewWebView(*width, *height)
+	defer view.Release()
+	if *allowOrigin != "" {
+		view.SetAllowedOrigins(strings.Split(*allowOrigin, ",")...)
+	}
+	server := &http.Server{Addr: *addr, Handler: view}
+
+	s := snake.NewSnake(*width, *height)
+	food := snake.NewFood(*width, *height)
+	game := snake.NewGame(s, snake.RealClock{}, food)
+	var bots []snake.Opponent
+	for i := 1; i <= *opponents; i++ {
+		bots = append(bots, snake.Opponent{Name: fmt.Sprintf("Bot %d", i), Strategy: *strategy, Seed: time.Now().UnixNano() + int64(i)})
+	}
+	if err := game.SetOpponents(bots); err != nil {
+		log.Fatal(err)
+	}
+	controller := snake.NewController(game, view)
+	if err := controller.SetMode(*mode); err != nil {
+		log.Fatalf("%v: %s", err, *mode)
+	}
+
+	if *watchAddr != "" {
+		watchView := snake.NewWebView(*width, *height)
+		defer watchView.Release()
+		watchView.SetReadOnly(true)
+		game.Watch(watchView)
+		watchServer := &http.Server{Addr: *watchAddr, Handler: watchView}
+		defer watchServer.Close()
+		go func() {
+			if err := watchServer.ListenAndServe(); err != http.ErrServerClosed {
+				log.Fatal(err)
+			}
+		}()
+		log.Printf("Open http://%s in a browser to watch", *watchAddr)
+	}
+
+	go controller.Start(time.Millisecond * 200)
+	go func() {
+		<-controller.WaitForQuitSignal()
+		view.Release()
+		server.Shutdown(context.Background())
+	}()
+
+	log.Printf("Open http://%s in a browser to play", *addr)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
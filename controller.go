@@ -1,6 +1,11 @@
 package snake
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
 
 // Controller struct coordinates a snake game with a view.
 type Controller struct {
@@ -10,49 +15,812 @@ type Controller struct {
 	lastFoodCoordinate  *Coordinate
 	gameInterval        time.Duration
 	quitC               chan struct{}
+	score               int
+	startTime           time.Time
+	mode                string
+	resizeBoard         bool
+	boardSize           *Size
+	state               State
+	menu                Menu
+	started             bool
+	pauseTime           time.Time
+	modes               []string
+	scores              []int
+	quitting            bool
+	saveFile            string
+	restored            *SaveState
+	err                 error
+	clock               Clock
+	entitiesC           <-chan []Entity
+	campaign            *Campaign
+	level               int
+	levelScore          int
+	leveled             bool
+	win                 WinCondition
+	rules               Rules
+	cutTail             bool
+	livesC              <-chan Life
+	lives               int
+	startLives          int
+	opponentsC          <-chan []OpponentState
+	arenaC              <-chan Bounds
+	scoreC              <-chan int
+	selectC             <-chan struct{}
+	pauseC              <-chan struct{}
+	saveC               <-chan struct{}
+	resizeC             <-chan Size
 }
 
+// DefaultMode is the game mode displayed in the status bar.
+const DefaultMode = "Classic"
+
 // NewController returns a Controller pointer initializing the game and the view.
+// If the game implements a ReceiveEntities() <-chan []Entity method, the
+// controller receives the entities too, and the same goes for the lives,
+// the opponents, the arena and the score. The menu, save and resize inputs
+// are received from the views which implement the methods sending them.
 func NewController(game GameDirector, view ViewHandler) *Controller {
-	quitChannel := make(chan struct{})
-	return &Controller{game, view, nil, nil, 0, quitChannel}
+	modes := make([]string, len(Modes))
+	for i, r := range Modes {
+		modes[i] = r.Mode
+	}
+	var entitiesChannel <-chan []Entity
+	if g, ok := game.(entityGame); ok {
+		entitiesChannel = g.ReceiveEntities()
+	}
+	var livesChannel <-chan Life
+	if g, ok := game.(lifeGame); ok {
+		livesChannel = g.ReceiveLives()
+	}
+	var opponentsChannel <-chan []OpponentState
+	if g, ok := game.(opponentGame); ok {
+		opponentsChannel = g.ReceiveOpponents()
+	}
+	var arenaChannel <-chan Bounds
+	if g, ok := game.(arenaGame); ok {
+		arenaChannel = g.ReceiveArena()
+	}
+	var scoreChannel <-chan int
+	if g, ok := game.(scoreGame); ok {
+		scoreChannel = g.ReceiveScore()
+	}
+	var selectChannel, pauseChannel, saveChannel <-chan struct{}
+	if v, ok := view.(menuView); ok {
+		selectChannel, pauseChannel = v.ReceiveSelectSignal(), v.ReceivePauseSignal()
+	}
+	if v, ok := view.(saveView); ok {
+		saveChannel = v.ReceiveSaveSignal()
+	}
+	var resizeChannel <-chan Size
+	if v, ok := view.(resizeView); ok {
+		resizeChannel = v.ReceiveResize()
+	}
+	return &Controller{
+		game:       game,
+		view:       view,
+		quitC:      make(chan struct{}),
+		mode:       DefaultMode,
+		state:      TitleState,
+		modes:      modes,
+		clock:      RealClock{},
+		entitiesC:  entitiesChannel,
+		rules:      ClassicRules,
+		livesC:     livesChannel,
+		opponentsC: opponentsChannel,
+		arenaC:     arenaChannel,
+		scoreC:     scoreChannel,
+		selectC:    selectChannel,
+		pauseC:     pauseChannel,
+		saveC:      saveChannel,
+		resizeC:    resizeChannel,
+	}
 }
 
-// Start starts the controller internal game, then loops and waits on the view
-// direction channel, on the game snake coordinates receiver channel, on
+// Start displays the title menu, or starts playing the restored game or, if the view does
+// not display the menus, a new game, then loops and waits on the view
+// input channels, on the game snake coordinates receiver channel, on
 // the game food coordinate receiver channel and on the game result receiver channel.
-// When it receives a new direction from the view it sends it to the game.
-// When it receives new snake or food coordinates it refreshes the view screen.
-// When it receives a game result it display win or lose accordingly to the result.
+// The controller moves between the title, mode select, settings, playing,
+// paused, game over, leaderboard and help states:
+// in the menus the Up and Down directions move the menu selection and the
+// select signal activates the selected item, while the pause signal goes
+// back to the title menu. The game is started when the Play item is selected
+// or when the view sends a new game signal.
+// While playing, when it receives a new direction from the view it sends it to the game
+// and when it receives the pause signal it pauses the game.
+// When it receives new snake or food coordinates it refreshes the view screen
+// and the view status, setting the snake face on the view if the game tells
+// it and the view implements a SetFace(Direction) method. When it receives
+// the score it displays it in the status bar, added to the score carried
+// into the campaign level.
+// When it receives the entities it sets them on the view, if the view
+// implements a SetEntities([]Entity) method. When it receives the opponents
+// it sets them on the view, if the view implements
+// a SetOpponents([]OpponentState) method. When it receives the arena it
+// sets the walls on the view, if the view implements a SetArena(Bounds) method.
+// When it receives a game result it display win or lose accordingly to the result
+// and records the score in the leaderboard. In the campaign mode a won level
+// unlocks the next one and displays its intro, carrying the score forward,
+// until the last level is won.
+// When the view is resized and the board resize is enabled, the next game
+// is played on the board size which fits the resized view.
+// If a save file is set, the game in progress is saved when the controller
+// receives the save signal, which pauses the game, and before quitting.
+// After quitting the controller sends on the WaitForQuitSignal channel.
 //
 // Should be used as a go routine.
 func (c *Controller) Start(d time.Duration) {
+	c.Run(context.Background(), d)
+	c.quitC <- struct{}{}
+}
+
+// Run runs the controller like Start, blocking until the view sends the quit
+// signal or ctx is done. The game in progress is saved and the game quits
+// in both cases, then Run returns nil if the view sent the quit signal,
+// or ctx.Err(). It does not send on the WaitForQuitSignal channel.
+func (c *Controller) Run(ctx context.Context, d time.Duration) error {
 	c.gameInterval = d
-	c.game.Start(d)
+	if _, ok := c.view.(menuView); ok && c.restored == nil {
+		c.displayTitle()
+	} else {
+		c.newGame()
+	}
 	for {
 		select {
 		case dir := <-c.view.ReceiveDirection():
-			c.game.SendMove(dir)
+			c.handleDirection(dir)
+		case <-c.selectC:
+			c.handleSelect()
+		case <-c.pauseC:
+			c.handlePause()
+		case <-c.saveC:
+			c.handleSave()
 		case <-c.view.ReceiveNewGameSignal():
-			c.game.Restart(c.gameInterval)
+			c.handleNewGame()
+		case size := <-c.resizeC:
+			if c.resizeBoard {
+				c.boardSize = &size
+			}
 		case <-c.view.ReceiveQuitSignal():
-			c.game.Quit()
-			c.quitC <- struct{}{}
-			return
+			c.quitting = true
+		case <-ctx.Done():
+			c.quitting = true
 		case sc := <-c.game.ReceiveSnakeCoordinates():
 			c.lastSnakeCoordinate = &sc
-			c.view.Refresh(c.lastSnakeCoordinate, c.lastFoodCoordinate)
+			c.handleFace()
+			c.refresh()
 		case fc := <-c.game.ReceiveFoodCoordinate():
 			c.lastFoodCoordinate = &fc
-			c.view.Refresh(c.lastSnakeCoordinate, c.lastFoodCoordinate)
+			c.refresh()
+		case s := <-c.scoreC:
+			c.handleScore(s)
+		case e := <-c.entitiesC:
+			c.handleEntities(e)
+		case o := <-c.opponentsC:
+			c.handleOpponents(o)
+		case b := <-c.arenaC:
+			c.handleArena(b)
+		case l := <-c.livesC:
+			c.handleLife(l)
 		case r := <-c.game.ReceiveGameResult():
-			if r {
-				c.view.DisplayWin()
+			c.handleResult(r)
+		}
+		if c.quitting {
+			if c.state == PlayingState || c.state == PausedState {
+				c.save()
+			}
+			if c.started {
+				c.game.Quit()
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// State returns the controller current state.
+// It should not be called concurrently with Start.
+func (c *Controller) State() State {
+	return c.state
+}
+
+// handleResult displays win or lose and records the score, or displays
+// the next level intro when a campaign level which is not the last is won.
+func (c *Controller) handleResult(won bool) {
+	if won && c.mode == CampaignMode && c.state == PlayingState {
+		if err := c.campaign.Unlock(c.level + 1); err != nil {
+			c.err = err
+		}
+		if c.level+1 < len(c.campaign.Levels) {
+			c.level++
+			c.levelScore = c.score
+			c.displayLevelIntro()
+			return
+		}
+	}
+	c.state = GameOverState
+	c.recordScore()
+	if won {
+		c.view.DisplayWin()
+	} else {
+		c.view.DisplayLose()
+	}
+}
+
+// handleNewGame starts a new game, or displays the current level intro
+// in the campaign mode, then starts the level from its intro.
+func (c *Controller) handleNewGame() {
+	switch {
+	case c.mode != CampaignMode:
+		c.newGame()
+	case c.state == LevelIntroState:
+		c.startLevel()
+	default:
+		c.displayLevelIntro()
+	}
+}
+
+// handleScore sets the game score, adding the score carried into the
+// campaign level. The view is refreshed by the snake coordinates which
+// follow the score.
+func (c *Controller) handleScore(score int) {
+	if c.mode == CampaignMode {
+		score += c.levelScore
+	}
+	c.score = score
+}
+
+// handleEntities sets the entities on the view. The view is refreshed
+// by the snake coordinates which follow the entities.
+func (c *Controller) handleEntities(entities []Entity) {
+	if v, ok := c.view.(entityView); ok {
+		v.SetEntities(entities)
+	}
+}
+
+// handleOpponents sets the opponents on the view. The view is refreshed
+// by the snake coordinates which follow the opponents.
+func (c *Controller) handleOpponents(opponents []OpponentState) {
+	if v, ok := c.view.(opponentView); ok {
+		v.SetOpponents(opponents)
+	}
+}
+
+// handleLife sets the lives left displayed in the status bar and the snake
+// invulnerability on the view, if the view implements a SetInvulnerable(int)
+// method. The view is refreshed by the snake coordinates which follow the
+// lives, or right away when the lives are over, as the game result follows.
+func (c *Controller) handleLife(l Life) {
+	c.lives = l.Left
+	if v, ok := c.view.(lifeView); ok {
+		v.SetInvulnerable(l.Invulnerable)
+	}
+	if l.Left == 0 {
+		c.refresh()
+	}
+}
+
+// handleDirection sends d to the game while playing,
+// or moves the menu selection on the menu states.
+func (c *Controller) handleDirection(d Direction) {
+	switch c.state {
+	case PlayingState:
+		c.game.SendMove(d)
+	case GameOverState:
+	default:
+		switch d {
+		case Up:
+			c.menu.Previous()
+		case Down:
+			c.menu.Next()
+		default:
+			return
+		}
+		c.displayMenu()
+	}
+}
+
+// handleSelect activates the selected menu item.
+func (c *Controller) handleSelect() {
+	item := c.menu.Item()
+	switch c.state {
+	case TitleState:
+		switch item {
+		case PlayItem:
+			if c.mode == CampaignMode {
+				c.displayLevelSelect()
 			} else {
-				c.view.DisplayLose()
+				c.newGame()
 			}
+		case ModeItem:
+			c.displayModeSelect()
+		case SettingsItem:
+			c.displaySettings()
+		case LeaderboardItem:
+			c.displayLeaderboard()
+		case HelpItem:
+			c.displayHelp()
+		case QuitItem:
+			c.quitting = true
+		}
+	case ModeSelectState:
+		if item != BackItem {
+			c.mode = item
+		}
+		c.displayTitle()
+	case SettingsState:
+		if item == BackItem {
+			c.displayTitle()
+			return
+		}
+		if c.menu.Selected == 0 {
+			c.gameInterval = nextSpeed(c.gameInterval)
+		} else {
+			c.cutTail = !c.cutTail
 		}
+		c.displaySettings()
+	case LevelSelectState:
+		if item == BackItem {
+			c.displayTitle()
+			return
+		}
+		c.level, c.levelScore = c.menu.Selected, 0
+		c.displayLevelIntro()
+	case LevelIntroState:
+		if item == StartItem {
+			c.startLevel()
+		} else {
+			c.displayTitle()
+		}
+	case PausedState:
+		switch item {
+		case ResumeItem:
+			c.resume()
+		case RestartItem:
+			c.handleNewGame()
+		case SaveItem:
+			c.handleSave()
+		case MainMenuItem:
+			c.displayTitle()
+		case QuitItem:
+			c.quitting = true
+		}
+	case GameOverState, LeaderboardState, HelpState:
+		c.displayTitle()
+	}
+}
+
+// handlePause pauses the game while playing, resumes the game while paused
+// or goes back to the title menu.
+func (c *Controller) handlePause() {
+	switch c.state {
+	case PlayingState:
+		c.game.Pause()
+		c.pauseTime = c.clock.Now()
+		c.displayPaused(nil)
+	case PausedState:
+		c.resume()
+	case TitleState:
+	default:
+		c.displayTitle()
+	}
+}
+
+// handleSave pauses the game while playing, then saves it
+// and displays the paused menu with the save outcome.
+func (c *Controller) handleSave() {
+	switch c.state {
+	case PlayingState:
+		c.game.Pause()
+		c.pauseTime = c.clock.Now()
+	case PausedState:
+	default:
+		return
+	}
+	text := []string{SavedMessage}
+	if err := c.save(); err != nil {
+		text = []string{err.Error()}
+	}
+	c.displayPaused(text)
+}
+
+// save saves the game to the save file, if set,
+// keeping the error to be returned by Err. It returns ErrUnsavableGoal
+// without saving if no Goal expresses the win condition of the game.
+func (c *Controller) save() error {
+	if c.saveFile == "" {
+		return nil
+	}
+	if _, ok := goalOf(c.win); !ok && c.mode != CampaignMode {
+		c.err = ErrUnsavableGoal
+		return c.err
+	}
+	c.err = SaveFile(c.saveFile, c.game.Save())
+	return c.err
+}
+
+// displayPaused displays the paused menu with text, keeping the selected item.
+func (c *Controller) displayPaused(text []string) {
+	selected := 0
+	if c.state == PausedState {
+		selected = c.menu.Selected
+	}
+	c.state = PausedState
+	items := []string{ResumeItem, RestartItem, MainMenuItem, QuitItem}
+	if c.saveFile != "" {
+		items = []string{ResumeItem, RestartItem, SaveItem, MainMenuItem, QuitItem}
+	}
+	c.menu = Menu{Title: PausedState.String(), Text: text, Items: items, Selected: selected}
+	c.displayMenu()
+}
+
+// newGame starts the game the first time, then restarts it,
+// resizing the board if the view was resized. In the campaign mode the
+// score carried into the level is kept, otherwise the board set by the
+// last campaign level is cleared. A restored game keeps its saved rules
+// and lives.
+func (c *Controller) newGame() {
+	c.reset()
+	if c.mode == CampaignMode {
+		c.score = c.levelScore
+	} else if c.leveled {
+		c.leveled = false
+		c.setLevel(Level{})
+		c.setWinCondition()
+	}
+	if c.restored != nil {
+		if _, ok := findRules(c.restored.Rules.Mode); ok {
+			c.mode = c.restored.Rules.Mode
+		}
+		c.score = c.restored.Score
+		c.gameInterval = c.restored.Interval
+		c.startTime = c.startTime.Add(-time.Duration(c.restored.Tick) * c.restored.Interval)
+		c.rules = c.restored.Rules
+		c.restored = nil
+	} else {
+		c.setRules()
+	}
+	c.state = PlayingState
+	if !c.started {
+		c.started = true
+		c.game.Start(c.gameInterval)
+		return
+	}
+	resized := c.boardSize != nil
+	if resized {
+		c.game.Resize(c.boardSize.Width, c.boardSize.Height)
+		c.setBoardSize(c.boardSize.Width, c.boardSize.Height)
+		c.boardSize = nil
+	}
+	c.game.Restart(c.gameInterval)
+	if g, ok := c.game.(portalGame); ok && resized {
+		if v, ok := c.view.(portalView); ok {
+			v.SetPortals(g.Portals())
+		}
+	}
+}
+
+// resume resumes the paused game, not counting the pause in the elapsed time.
+func (c *Controller) resume() {
+	c.startTime = c.startTime.Add(c.clock.Now().Sub(c.pauseTime))
+	c.state = PlayingState
+	c.game.Resume()
+	c.refresh()
+}
+
+// startLevel sets the current campaign level on the game and the view,
+// then starts playing it.
+func (c *Controller) startLevel() {
+	l := c.campaign.Levels[c.level]
+	if l.Interval > 0 {
+		c.gameInterval = l.Interval
+	}
+	if l.Width > 0 && l.Height > 0 {
+		c.boardSize = nil
+		c.setBoardSize(l.Width, l.Height)
+	}
+	c.leveled = true
+	c.setLevel(l)
+	c.newGame()
+}
+
+// setLevel sets l on the game, if it implements a SetLevel(Level) error
+// method, and the level portals on the view, if it implements
+// a SetPortals([]Portal) method.
+func (c *Controller) setLevel(l Level) {
+	g, ok := c.game.(levelGame)
+	if !ok {
+		return
+	}
+	if err := g.SetLevel(l); err != nil {
+		c.err = err
+		return
+	}
+	if v, ok := c.view.(portalView); ok {
+		v.SetPortals(l.Portals)
+	}
+}
+
+// setRules sets the rules of the selected mode on the game, if the game
+// implements a SetRules(Rules) method. The campaign levels are played
+// with the classic rules. The tail cutting setting cuts the tail in every mode.
+func (c *Controller) setRules() {
+	r, ok := findRules(c.mode)
+	if !ok {
+		r = ClassicRules
+	}
+	r.CutTail = r.CutTail || c.cutTail
+	if c.startLives > 0 {
+		r.Lives = c.startLives
+	}
+	c.rules = r
+	if g, ok := c.game.(rulesGame); ok {
+		g.SetRules(r)
+	}
+}
+
+// setWinCondition sets the win condition of the other modes on the game,
+// if the game implements a SetWinCondition(WinCondition) method.
+func (c *Controller) setWinCondition() {
+	if g, ok := c.game.(winGame); ok {
+		g.SetWinCondition(c.win)
+	}
+}
+
+// recordScore adds the score to the leaderboard, keeping the best scores.
+func (c *Controller) recordScore() {
+	c.scores = append(c.scores, c.score)
+	sort.Sort(sort.Reverse(sort.IntSlice(c.scores)))
+	if len(c.scores) > LeaderboardSize {
+		c.scores = c.scores[:LeaderboardSize]
+	}
+}
+
+// displayMenu displays the current menu, if the view implements
+// a DisplayMenu(Menu) method.
+func (c *Controller) displayMenu() {
+	if v, ok := c.view.(menuView); ok {
+		v.DisplayMenu(c.menu)
+	}
+}
+
+// setBoardSize sets the board size displayed by the view, if the view
+// implements a SetBoardSize(int, int) method.
+func (c *Controller) setBoardSize(width, height int) {
+	if v, ok := c.view.(resizeView); ok {
+		v.SetBoardSize(width, height)
+	}
+}
+
+func (c *Controller) displayTitle() {
+	c.state = TitleState
+	c.menu = Menu{Title: "Snake", Items: []string{PlayItem, ModeItem, SettingsItem, LeaderboardItem, HelpItem, QuitItem}}
+	c.displayMenu()
+}
+
+func (c *Controller) displayLevelSelect() {
+	c.state = LevelSelectState
+	items := make([]string, 0, c.campaign.Unlocked()+1)
+	for i, l := range c.campaign.Levels[:c.campaign.Unlocked()] {
+		items = append(items, fmt.Sprintf("%d. %s", i+1, l.Name))
+	}
+	c.menu = Menu{Title: LevelSelectState.String(), Items: append(items, BackItem)}
+	if c.level < c.campaign.Unlocked() {
+		c.menu.Selected = c.level
+	}
+	c.displayMenu()
+}
+
+func (c *Controller) displayLevelIntro() {
+	c.state = LevelIntroState
+	l := c.campaign.Levels[c.level]
+	c.menu = Menu{
+		Title: fmt.Sprintf("Level %d: %s", c.level+1, l.Name),
+		Text:  []string{l.Goal.String() + ".", fmt.Sprintf("Score: %d", c.levelScore)},
+		Items: []string{StartItem, MainMenuItem},
+	}
+	c.displayMenu()
+}
+
+func (c *Controller) displayModeSelect() {
+	c.state = ModeSelectState
+	c.menu = Menu{Title: ModeSelectState.String(), Items: append(append([]string{}, c.modes...), BackItem)}
+	for i, m := range c.modes {
+		if m == c.mode {
+			c.menu.Selected = i
+		}
+	}
+	c.displayMenu()
+}
+
+func (c *Controller) displaySettings() {
+	selected := 0
+	if c.state == SettingsState {
+		selected = c.menu.Selected
+	}
+	c.state = SettingsState
+	cutTail := "Off"
+	if c.cutTail {
+		cutTail = "On"
+	}
+	c.menu = Menu{Title: SettingsState.String(), Items: []string{fmt.Sprintf("Speed: %v", c.gameInterval), fmt.Sprintf("Tail cutting: %s", cutTail), BackItem}, Selected: selected}
+	c.displayMenu()
+}
+
+func (c *Controller) displayLeaderboard() {
+	c.state = LeaderboardState
+	text := []string{"No games played yet."}
+	if len(c.scores) > 0 {
+		text = make([]string, len(c.scores))
+		for i, s := range c.scores {
+			text[i] = fmt.Sprintf("%2d. %d", i+1, s)
+		}
+	}
+	c.menu = Menu{Title: LeaderboardState.String(), Text: text, Items: []string{BackItem}}
+	c.displayMenu()
+}
+
+func (c *Controller) displayHelp() {
+	c.state = HelpState
+	c.menu = Menu{Title: HelpState.String(), Text: HelpText, Items: []string{BackItem}}
+	c.displayMenu()
+}
+
+// nextSpeed returns the speed following d in Speeds,
+// or the first speed if d is not one of them.
+func nextSpeed(d time.Duration) time.Duration {
+	for i, s := range Speeds {
+		if s == d {
+			return Speeds[(i+1)%len(Speeds)]
+		}
+	}
+	return Speeds[0]
+}
+
+// SetSaveFile sets the path of the file on which the game in progress is
+// saved on the save signal and before quitting. An empty path disables
+// saving. Should be called before Start.
+func (c *Controller) SetSaveFile(path string) {
+	c.saveFile = path
+}
+
+// Restore restores the game state s, which Start plays instead of
+// displaying the title menu. Should be called before Start.
+func (c *Controller) Restore(s SaveState) error {
+	if err := c.game.Restore(s); err != nil {
+		return err
+	}
+	c.restored = &s
+	return nil
+}
+
+// Err returns the error of the last save, of the last campaign progress
+// save or of the last campaign level set, or nil if they succeeded.
+// It should not be called concurrently with Start.
+func (c *Controller) Err() error {
+	return c.err
+}
+
+// SetClock sets the clock measuring the game elapsed time of the games which
+// do not tell it, which defaults to RealClock. Should be called before Start.
+func (c *Controller) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+// SetCampaign adds the campaign mode to the mode select menu, playing the
+// campaign levels in order. The first campaign level played replaces the
+// board portals and entities, and the next games of the other modes are
+// played without them. Should be called before Start.
+func (c *Controller) SetCampaign(campaign *Campaign) {
+	c.campaign = campaign
+	c.modes = append(c.modes, CampaignMode)
+}
+
+// SetMode selects the game mode named name, ignoring the case and matching
+// dashes with spaces, like "time-attack" for the Time attack mode. It returns
+// ErrUnknownMode if the mode is not one of the mode select menu. Should be
+// called before Start, after SetCampaign to select the campaign mode.
+func (c *Controller) SetMode(name string) error {
+	for _, m := range c.modes {
+		if sameMode(name, m) {
+			c.mode = m
+			return nil
+		}
+	}
+	return ErrUnknownMode
+}
+
+// SetLives sets the number of lives of the games in every mode: the snake
+// respawns when it dies, keeping the score, and the game is over when the
+// lives are over. Zero keeps the lives of the mode rules.
+// Should be called before Start.
+func (c *Controller) SetLives(n int) {
+	c.startLives = n
+}
+
+// SetTailCutting sets whether the snake hitting its body bites off its tail
+// and goes on, in every mode, instead of losing. It can be changed from the
+// settings menu too. Should be called before Start.
+func (c *Controller) SetTailCutting(enabled bool) {
+	c.cutTail = enabled
+}
+
+// SetWinCondition sets the condition which wins the games of the modes
+// other than the campaign, whose levels have their own goals. A nil
+// condition wins only filling the board. Should be called before Start.
+func (c *Controller) SetWinCondition(w WinCondition) {
+	c.win = w
+	c.setWinCondition()
+}
+
+// SetResizeBoard enables or disables the board resize between games
+// after the view is resized. When disabled, the view keeps displaying
+// the board with its starting size. Should be called before Start.
+func (c *Controller) SetResizeBoard(enabled bool) {
+	c.resizeBoard = enabled
+}
+
+// handleFace sets the snake face direction on the view, if the game
+// tells it and the view draws it.
+func (c *Controller) handleFace() {
+	g, ok := c.game.(faceGame)
+	if !ok {
+		return
+	}
+	if v, ok := c.view.(faceView); ok {
+		v.SetFace(g.Face())
+	}
+}
+
+// handleArena sets the arena walls on the view. The view is refreshed
+// by the snake coordinates which follow the arena.
+func (c *Controller) handleArena(b Bounds) {
+	if v, ok := c.view.(arenaView); ok {
+		v.SetArena(b)
+	}
+}
+
+// reset clears the last game coordinates, score and arena walls before a new game.
+func (c *Controller) reset() {
+	c.lastSnakeCoordinate = nil
+	c.lastFoodCoordinate = nil
+	c.score = 0
+	c.lives = 0
+	c.handleArena(Bounds{})
+	c.startTime = c.clock.Now()
+}
+
+// elapsed returns the game time elapsed told by the game, if it implements
+// an Elapsed() time.Duration method, or measured by the clock otherwise.
+func (c *Controller) elapsed() time.Duration {
+	if g, ok := c.game.(elapsedGame); ok {
+		return g.Elapsed()
+	}
+	return c.clock.Now().Sub(c.startTime)
+}
+
+// refresh refreshes the view with the last coordinates and the game status,
+// if the game is playing.
+func (c *Controller) refresh() {
+	if c.state != PlayingState {
+		return
+	}
+	mode := c.mode
+	if mode == CampaignMode {
+		mode = fmt.Sprintf("%s %d/%d", CampaignMode, c.level+1, len(c.campaign.Levels))
+	}
+	status := Status{
+		Score:     c.score,
+		Speed:     c.gameInterval,
+		Mode:      mode,
+		Elapsed:   c.elapsed(),
+		TimeLimit: c.rules.TimeLimit,
+		Lives:     c.lives,
+		HasLives:  c.rules.Lives > 0,
+	}
+	if c.lastSnakeCoordinate != nil {
+		status.Length = len(*c.lastSnakeCoordinate)
 	}
+	refreshFrame(c.view, c.lastSnakeCoordinate, c.lastFoodCoordinate, status)
 }
 
 // WaitForQuitSignal returns an empty struct receiver channel on which
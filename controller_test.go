@@ -1,6 +1,10 @@
 package snake_test
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -17,12 +21,32 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
-		select {
-		case <-game.StartC:
-		case <-time.After(time.Millisecond * 5):
-			t.Error("game should have started")
+		<-game.StartC
+	})
+
+	t.Run("should play right away on a view without menus", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, handlerViewSpy{view})
+
+		go controller.Start(time.Microsecond)
+		<-game.StartC
+
+		game.SendSnakeCoordinates(t, snakeCoordinates)
+		snake.AssertCoordinates(t, *view.GetSnakeCoordinates(t), snakeCoordinates)
+		view.GetFoodCoordinate(t)
+		if len(view.StatusC) != 0 {
+			t.Error("should not have refreshed the status of a view without status")
 		}
+		game.SendResult(t, false)
+		<-view.LoseC
+		view.NewGameC <- struct{}{}
+		<-game.RestartC
+		view.QuitC <- struct{}{}
+		<-game.QuitC
+		<-controller.WaitForQuitSignal()
 	})
 
 	t.Run("should send move from view to game", func(t *testing.T) {
@@ -31,19 +55,11 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
-		select {
-		case view.DirectionC <- snake.Up:
-		case <-time.After(time.Millisecond * 5):
-			t.Errorf("should have sent direction %v from view", snake.Up)
-		}
+		view.DirectionC <- snake.Up
 
-		select {
-		case got := <-game.MoveC:
-			snake.AssertDirection(t, got, snake.Up)
-		case <-time.After(time.Millisecond * 5):
-			t.Error("should have received direction from game")
-		}
+		snake.AssertDirection(t, <-game.MoveC, snake.Up)
 	})
 
 	t.Run("should refresh view when game sends snake coordinates", func(t *testing.T) {
@@ -52,6 +68,7 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendSnakeCoordinates(t, snakeCoordinates)
 		gotSnake := view.GetSnakeCoordinates(t)
@@ -67,6 +84,7 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendFoodCoordinate(t, foodCoordinate)
 		gotSnake := view.GetSnakeCoordinates(t)
@@ -82,6 +100,7 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendFoodCoordinate(t, foodCoordinate)
 		view.GetSnakeCoordinates(t)
@@ -101,6 +120,7 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendSnakeCoordinates(t, snakeCoordinates)
 		view.GetSnakeCoordinates(t)
@@ -114,20 +134,74 @@ func TestController(t *testing.T) {
 		snake.AssertCoordinate(t, *gotFood, foodCoordinate)
 	})
 
+	t.Run("should refresh status with snake length and score", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+
+		game.SendSnakeCoordinates(t, snakeCoordinates)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		got := view.GetStatus(t)
+		assertStatus(t, got, 0, 1)
+
+		game.SendFoodCoordinate(t, foodCoordinate)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		got = view.GetStatus(t)
+		assertStatus(t, got, 0, 1)
+
+		game.SendScore(t, 1)
+		game.SendSnakeCoordinates(t, []snake.Coordinate{{0, 0}, {0, 1}})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		got = view.GetStatus(t)
+		assertStatus(t, got, 1, 2)
+		if got.Mode != snake.DefaultMode {
+			t.Errorf("got mode %q, want %q", got.Mode, snake.DefaultMode)
+		}
+		if got.Speed != time.Microsecond {
+			t.Errorf("got speed %v, want %v", got.Speed, time.Microsecond)
+		}
+	})
+
+	t.Run("should set the entities on the view", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+
+		entities := []snake.Entity{
+			{Kind: snake.Mouse, Position: snake.Coordinate{5, 5}, Eaten: true},
+			{Kind: snake.Block, Position: snake.Coordinate{6, 6}, Path: []snake.Coordinate{{6, 6}}},
+		}
+		game.SendEntities(t, entities)
+		assertEntities(t, <-view.EntitiesC, entities)
+		game.SendSnakeCoordinates(t, snakeCoordinates)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		got := view.GetStatus(t)
+		assertStatus(t, got, 0, 1)
+	})
+
 	t.Run("should display win when game send win result", func(t *testing.T) {
 		view := NewViewSpy()
 		game := NewGameSpy()
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendResult(t, true)
 		select {
 		case <-view.WinC:
 		case <-view.LoseC:
 			t.Error("view should have displayed a win instead of a lose")
-		case <-time.After(time.Millisecond * 5):
-			t.Error("view should have displayed a win")
 		}
 	})
 
@@ -137,14 +211,13 @@ func TestController(t *testing.T) {
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
 
 		game.SendResult(t, false)
 		select {
 		case <-view.LoseC:
 		case <-view.WinC:
 			t.Error("view should have diplayed a lose instead of a win")
-		case <-time.After(time.Millisecond * 5):
-			t.Error("view should have displayed a lose")
 		}
 	})
 
@@ -155,48 +228,335 @@ func TestController(t *testing.T) {
 
 		want := time.Microsecond
 		go controller.Start(want)
+		startPlaying(t, view)
 
-		select {
-		case view.NewGameC <- struct{}{}:
-		case <-time.After(time.Millisecond * 5):
-			t.Error("view should have received a new game signal")
+		view.NewGameC <- struct{}{}
+
+		got := <-game.RestartC
+		if got != want {
+			t.Errorf("got duration %v, want duration %v", got, want)
 		}
+	})
 
+	t.Run("should resize board on new game after view resize", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		controller.SetResizeBoard(true)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+
+		want := snake.Size{30, 20}
+		view.ResizeC <- want
+		view.NewGameC <- struct{}{}
+
+		assertSize(t, <-game.ResizeC, want)
+		assertSize(t, <-view.BoardSizeC, want)
+		<-game.RestartC
+	})
+
+	t.Run("should not resize board after view resize by default", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+
+		view.ResizeC <- snake.Size{30, 20}
+		view.NewGameC <- struct{}{}
+
+		<-game.RestartC
 		select {
-		case got := <-game.RestartC:
-			if got != want {
-				t.Errorf("got duration %v, want duration %v", got, want)
-			}
-		case <-time.After(time.Millisecond * 5):
-			t.Errorf("game should have restarted")
+		case got := <-game.ResizeC:
+			t.Errorf("game should not have been resized, got %v", got)
+		default:
 		}
 	})
 
-	t.Run("should exit when receiving quit signal from view", func(T *testing.T) {
+	t.Run("should exit when receiving quit signal from view", func(t *testing.T) {
 		view := NewViewSpy()
 		game := NewGameSpy()
 		controller := snake.NewController(game, view)
 
 		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+
+		view.QuitC <- struct{}{}
 
+		<-game.QuitC
+
+		<-controller.WaitForQuitSignal()
+	})
+
+	t.Run("should display title menu on start", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+
+		got := view.GetMenu(t)
+		assertMenu(t, got, "Snake", snake.PlayItem)
 		select {
-		case view.QuitC <- struct{}{}:
-		case <-time.After(time.Millisecond * 5):
-			t.Error("should have received a quit signal from view")
+		case <-game.StartC:
+			t.Error("game should not have started before selecting play")
+		default:
 		}
+	})
 
-		select {
-		case <-game.QuitC:
-		case <-time.After(time.Millisecond * 5):
-			t.Error("should have quit game")
+	t.Run("should move menu selection with directions", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		view.GetMenu(t)
+
+		view.SendDirection(t, snake.Down)
+		assertMenu(t, view.GetMenu(t), "Snake", snake.ModeItem)
+		view.SendDirection(t, snake.Up)
+		assertMenu(t, view.GetMenu(t), "Snake", snake.PlayItem)
+		view.SendDirection(t, snake.Up)
+		assertMenu(t, view.GetMenu(t), "Snake", snake.QuitItem)
+	})
+
+	t.Run("should pause and resume game", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		<-game.StartC
+
+		view.SendPause(t)
+		<-game.PauseC
+		assertMenu(t, view.GetMenu(t), snake.PausedState.String(), snake.ResumeItem)
+
+		view.SendPause(t)
+		<-game.ResumeC
+	})
+
+	t.Run("should go back to title menu after game over", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		game.SendResult(t, false)
+		<-view.LoseC
+
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), "Snake", snake.PlayItem)
+	})
+
+	t.Run("should record score on leaderboard", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		game.SendFoodCoordinate(t, foodCoordinate)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		game.SendScore(t, 1)
+		game.SendResult(t, false)
+		<-view.LoseC
+		view.SendSelect(t)
+		view.GetMenu(t)
+
+		for i := 0; i < 3; i++ {
+			view.SendDirection(t, snake.Down)
+			view.GetMenu(t)
+		}
+		view.SendSelect(t)
+		got := view.GetMenu(t)
+		if got.Title != snake.LeaderboardState.String() || len(got.Text) != 1 || got.Text[0] != " 1. 1" {
+			t.Errorf("got leaderboard %q with text %q, want %q with text %q", got.Title, got.Text, snake.LeaderboardState, []string{" 1. 1"})
 		}
+	})
 
-		select {
-		case <-controller.WaitForQuitSignal():
-		case <-time.After(time.Millisecond * 5):
-			t.Error("should have received a quit signal from controller")
+	t.Run("should change speed from settings", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(snake.Speeds[0])
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), snake.SettingsState.String(), fmt.Sprintf("Speed: %v", snake.Speeds[0]))
+
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), snake.SettingsState.String(), fmt.Sprintf("Speed: %v", snake.Speeds[1]))
+		view.SendPause(t)
+		view.GetMenu(t)
+		view.SendSelect(t)
+
+		<-game.StartC
+		game.SendSnakeCoordinates(t, snakeCoordinates)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if got := view.GetStatus(t); got.Speed != snake.Speeds[1] {
+			t.Errorf("got speed %v, want %v", got.Speed, snake.Speeds[1])
+		}
+	})
+
+	t.Run("should save the game on save signal", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		game.SaveState = newSaveState(t)
+		controller := snake.NewController(game, view)
+		path := filepath.Join(t.TempDir(), "save.json")
+		controller.SetSaveFile(path)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		view.SaveC <- struct{}{}
+
+		<-game.PauseC
+		got := view.GetMenu(t)
+		if got.Title != snake.PausedState.String() || len(got.Text) != 1 || got.Text[0] != snake.SavedMessage {
+			t.Errorf("got menu %q with text %q, want %q with text %q", got.Title, got.Text, snake.PausedState, snake.SavedMessage)
+		}
+		saved, err := snake.LoadFile(path)
+		snake.AssertNoError(t, err)
+		if !reflect.DeepEqual(saved, game.SaveState) {
+			t.Errorf("got saved state %+v, want %+v", saved, game.SaveState)
+		}
+	})
+
+	t.Run("should save the game from the paused menu", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		game.SaveState = newSaveState(t)
+		controller := snake.NewController(game, view)
+		path := filepath.Join(t.TempDir(), "save.json")
+		controller.SetSaveFile(path)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		<-game.StartC
+		view.SendPause(t)
+		<-game.PauseC
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		assertMenu(t, view.GetMenu(t), snake.PausedState.String(), snake.SaveItem)
+		view.SendSelect(t)
+
+		got := view.GetMenu(t)
+		if got.Item() != snake.SaveItem || len(got.Text) != 1 || got.Text[0] != snake.SavedMessage {
+			t.Errorf("got menu with %q selected and text %q, want %q selected and text %q", got.Item(), got.Text, snake.SaveItem, snake.SavedMessage)
+		}
+		saved, err := snake.LoadFile(path)
+		snake.AssertNoError(t, err)
+		if !reflect.DeepEqual(saved, game.SaveState) {
+			t.Errorf("got saved state %+v, want %+v", saved, game.SaveState)
+		}
+	})
+
+	t.Run("should save the game before quitting", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		game.SaveState = newSaveState(t)
+		controller := snake.NewController(game, view)
+		path := filepath.Join(t.TempDir(), "save.json")
+		controller.SetSaveFile(path)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		view.QuitC <- struct{}{}
+		<-game.QuitC
+		<-controller.WaitForQuitSignal()
+
+		snake.AssertNoError(t, controller.Err())
+		_, err := snake.LoadFile(path)
+		snake.AssertNoError(t, err)
+	})
+
+	t.Run("should play the restored game", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		state := newSaveState(t)
+		state.Score = 7
+		state.Interval = snake.Speeds[2]
+
+		err := controller.Restore(state)
+		snake.AssertNoError(t, err)
+		if got := <-game.RestoreC; !reflect.DeepEqual(got, state) {
+			t.Errorf("got restored state %+v, want %+v", got, state)
+		}
+		go controller.Start(time.Microsecond)
+
+		<-game.StartC
+		game.SendSnakeCoordinates(t, state.Snake)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if got := view.GetStatus(t); got.Score != 7 || got.Speed != snake.Speeds[2] {
+			t.Errorf("got status %+v, want the restored score and speed", got)
 		}
 	})
+
+	t.Run("should save and quit the game when the context is canceled", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		game.SaveState = newSaveState(t)
+		controller := snake.NewController(game, view)
+		path := filepath.Join(t.TempDir(), "save.json")
+		controller.SetSaveFile(path)
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error)
+
+		go func() {
+			errC <- controller.Run(ctx, time.Microsecond)
+		}()
+		startPlaying(t, view)
+		cancel()
+
+		<-game.QuitC
+		snake.AssertError(t, <-errC, context.Canceled)
+		_, err := snake.LoadFile(path)
+		snake.AssertNoError(t, err)
+	})
+
+	t.Run("should return nil from Run when receiving quit signal from view", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		errC := make(chan error)
+
+		go func() {
+			errC <- controller.Run(context.Background(), time.Microsecond)
+		}()
+		view.GetMenu(t)
+		view.QuitC <- struct{}{}
+
+		snake.AssertNoError(t, <-errC)
+	})
+
+	t.Run("should quit from title menu", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Up)
+		view.GetMenu(t)
+		view.SendSelect(t)
+
+		<-controller.WaitForQuitSignal()
+	})
 }
 
 type GameSpy struct {
@@ -207,6 +567,17 @@ type GameSpy struct {
 	MoveC             chan snake.Direction
 	RestartC          chan time.Duration
 	QuitC             chan struct{}
+	ResizeC           chan snake.Size
+	PauseC            chan struct{}
+	ResumeC           chan struct{}
+	RestoreC          chan snake.SaveState
+	SaveState         snake.SaveState
+	EntitiesC         chan []snake.Entity
+	LivesC            chan snake.Life
+	OpponentsC        chan []snake.OpponentState
+	ArenaC            chan snake.Bounds
+	ScoreC            chan int
+	Rules             snake.Rules
 }
 
 func NewGameSpy() *GameSpy {
@@ -217,6 +588,10 @@ func NewGameSpy() *GameSpy {
 	moveChannel := make(chan snake.Direction)
 	restartChannel := make(chan time.Duration)
 	quitChannel := make(chan struct{})
+	resizeChannel := make(chan snake.Size, 1)
+	pauseChannel := make(chan struct{}, 1)
+	resumeChannel := make(chan struct{}, 1)
+	restoreChannel := make(chan snake.SaveState, 1)
 	return &GameSpy{
 		StartC:            startChannel,
 		SnakeCoordinatesC: snakeCoordiantesChannel,
@@ -225,6 +600,15 @@ func NewGameSpy() *GameSpy {
 		MoveC:             moveChannel,
 		RestartC:          restartChannel,
 		QuitC:             quitChannel,
+		ResizeC:           resizeChannel,
+		PauseC:            pauseChannel,
+		ResumeC:           resumeChannel,
+		RestoreC:          restoreChannel,
+		EntitiesC:         make(chan []snake.Entity),
+		LivesC:            make(chan snake.Life),
+		OpponentsC:        make(chan []snake.OpponentState),
+		ArenaC:            make(chan snake.Bounds),
+		ScoreC:            make(chan int),
 	}
 }
 
@@ -248,6 +632,26 @@ func (g *GameSpy) ReceiveGameResult() <-chan bool {
 	return g.ResultC
 }
 
+func (g *GameSpy) ReceiveEntities() <-chan []snake.Entity {
+	return g.EntitiesC
+}
+
+func (g *GameSpy) ReceiveLives() <-chan snake.Life {
+	return g.LivesC
+}
+
+func (g *GameSpy) ReceiveOpponents() <-chan []snake.OpponentState {
+	return g.OpponentsC
+}
+
+func (g *GameSpy) ReceiveArena() <-chan snake.Bounds {
+	return g.ArenaC
+}
+
+func (g *GameSpy) ReceiveScore() <-chan int {
+	return g.ScoreC
+}
+
 func (g *GameSpy) Restart(d time.Duration) {
 	g.RestartC <- d
 }
@@ -256,31 +660,69 @@ func (g *GameSpy) Quit() {
 	g.QuitC <- struct{}{}
 }
 
+func (g *GameSpy) Resize(width, height int) {
+	g.ResizeC <- snake.Size{width, height}
+}
+
+func (g *GameSpy) Pause() {
+	g.PauseC <- struct{}{}
+}
+
+func (g *GameSpy) Resume() {
+	g.ResumeC <- struct{}{}
+}
+
+func (g *GameSpy) SetRules(r snake.Rules) {
+	g.Rules = r
+}
+
+func (g *GameSpy) Save() snake.SaveState {
+	return g.SaveState
+}
+
+func (g *GameSpy) Restore(s snake.SaveState) error {
+	g.RestoreC <- s
+	return nil
+}
+
 func (g *GameSpy) SendResult(t testing.TB, result bool) {
 	t.Helper()
-	select {
-	case g.ResultC <- result:
-	case <-time.After(time.Millisecond * 5):
-		t.Error("should have received game result")
-	}
+	g.ResultC <- result
 }
 
 func (g *GameSpy) SendSnakeCoordinates(t testing.TB, c []snake.Coordinate) {
 	t.Helper()
-	select {
-	case g.SnakeCoordinatesC <- c:
-	case <-time.After(time.Millisecond * 5):
-		t.Errorf("should have sent snake coordinates %v from game", c)
-	}
+	g.SnakeCoordinatesC <- c
+}
+
+func (g *GameSpy) SendEntities(t testing.TB, e []snake.Entity) {
+	t.Helper()
+	g.EntitiesC <- e
+}
+
+func (g *GameSpy) SendLife(t testing.TB, l snake.Life) {
+	t.Helper()
+	g.LivesC <- l
+}
+
+func (g *GameSpy) SendOpponents(t testing.TB, o []snake.OpponentState) {
+	t.Helper()
+	g.OpponentsC <- o
+}
+
+func (g *GameSpy) SendScore(t testing.TB, score int) {
+	t.Helper()
+	g.ScoreC <- score
+}
+
+func (g *GameSpy) SendArena(t testing.TB, b snake.Bounds) {
+	t.Helper()
+	g.ArenaC <- b
 }
 
 func (g *GameSpy) SendFoodCoordinate(t testing.TB, f snake.Coordinate) {
 	t.Helper()
-	select {
-	case g.FoodCoordinateC <- f:
-	case <-time.After(time.Millisecond * 5):
-		t.Errorf("should have sent food coordiante %v from game", f)
-	}
+	g.FoodCoordinateC <- f
 }
 
 type ViewSpy struct {
@@ -291,6 +733,22 @@ type ViewSpy struct {
 	LoseC             chan struct{}
 	NewGameC          chan struct{}
 	QuitC             chan struct{}
+	StatusC           chan snake.Status
+	ResizeC           chan snake.Size
+	BoardSizeC        chan snake.Size
+	MenuC             chan snake.Menu
+	SelectC           chan struct{}
+	PauseC            chan struct{}
+	SaveC             chan struct{}
+	EntitiesC         chan []snake.Entity
+	InvulnerableC     chan int
+	OpponentsC        chan []snake.OpponentState
+	ArenaC            chan snake.Bounds
+}
+
+// handlerViewSpy exposes only the ViewHandler methods of a ViewSpy.
+type handlerViewSpy struct {
+	snake.ViewHandler
 }
 
 func NewViewSpy() *ViewSpy {
@@ -301,6 +759,13 @@ func NewViewSpy() *ViewSpy {
 	loseChannel := make(chan struct{})
 	newGameChannel := make(chan struct{})
 	quitChannel := make(chan struct{})
+	statusChannel := make(chan snake.Status, 16)
+	resizeChannel := make(chan snake.Size)
+	boardSizeChannel := make(chan snake.Size, 1)
+	menuChannel := make(chan snake.Menu, 16)
+	selectChannel := make(chan struct{})
+	pauseChannel := make(chan struct{})
+	saveChannel := make(chan struct{})
 	return &ViewSpy{
 		DirectionC:        directionChannel,
 		SnakeCoordinatesC: snakeChannel,
@@ -309,6 +774,17 @@ func NewViewSpy() *ViewSpy {
 		LoseC:             loseChannel,
 		NewGameC:          newGameChannel,
 		QuitC:             quitChannel,
+		StatusC:           statusChannel,
+		ResizeC:           resizeChannel,
+		BoardSizeC:        boardSizeChannel,
+		MenuC:             menuChannel,
+		SelectC:           selectChannel,
+		PauseC:            pauseChannel,
+		SaveC:             saveChannel,
+		EntitiesC:         make(chan []snake.Entity, 1),
+		InvulnerableC:     make(chan int, 1),
+		OpponentsC:        make(chan []snake.OpponentState, 1),
+		ArenaC:            make(chan snake.Bounds, 1),
 	}
 }
 
@@ -317,6 +793,34 @@ func (v *ViewSpy) Refresh(snakeCoordinates *[]snake.Coordinate, foodCoordinate *
 	v.FoodCoordinateC <- foodCoordinate
 }
 
+func (v *ViewSpy) SetEntities(entities []snake.Entity) {
+	select {
+	case v.EntitiesC <- entities:
+	default:
+	}
+}
+
+func (v *ViewSpy) SetOpponents(opponents []snake.OpponentState) {
+	select {
+	case v.OpponentsC <- opponents:
+	default:
+	}
+}
+
+func (v *ViewSpy) SetArena(b snake.Bounds) {
+	select {
+	case v.ArenaC <- b:
+	default:
+	}
+}
+
+func (v *ViewSpy) SetInvulnerable(ticks int) {
+	select {
+	case v.InvulnerableC <- ticks:
+	default:
+	}
+}
+
 func (v *ViewSpy) ReceiveDirection() <-chan snake.Direction {
 	return v.DirectionC
 }
@@ -337,25 +841,103 @@ func (v *ViewSpy) ReceiveQuitSignal() <-chan struct{} {
 	return v.QuitC
 }
 
-func (v *ViewSpy) GetSnakeCoordinates(t testing.TB) *[]snake.Coordinate {
-	t.Helper()
+func (v *ViewSpy) RefreshStatus(s snake.Status) {
+	select {
+	case v.StatusC <- s:
+	default:
+	}
+}
+
+func (v *ViewSpy) SetBoardSize(width, height int) {
+	v.BoardSizeC <- snake.Size{width, height}
+}
+
+func (v *ViewSpy) ReceiveResize() <-chan snake.Size {
+	return v.ResizeC
+}
+
+func (v *ViewSpy) DisplayMenu(m snake.Menu) {
 	select {
-	case c := <-v.SnakeCoordinatesC:
-		return c
-	case <-time.After(time.Millisecond * 5):
-		t.Error("should have received snake coordinates from view")
-		return nil
+	case v.MenuC <- m:
+	default:
 	}
 }
 
+func (v *ViewSpy) ReceiveSelectSignal() <-chan struct{} {
+	return v.SelectC
+}
+
+func (v *ViewSpy) ReceivePauseSignal() <-chan struct{} {
+	return v.PauseC
+}
+
+func (v *ViewSpy) ReceiveSaveSignal() <-chan struct{} {
+	return v.SaveC
+}
+
+func (v *ViewSpy) GetMenu(t testing.TB) snake.Menu {
+	t.Helper()
+	return <-v.MenuC
+}
+
+func (v *ViewSpy) SendSelect(t testing.TB) {
+	t.Helper()
+	v.SelectC <- struct{}{}
+}
+
+func (v *ViewSpy) SendPause(t testing.TB) {
+	t.Helper()
+	v.PauseC <- struct{}{}
+}
+
+func (v *ViewSpy) SendDirection(t testing.TB, d snake.Direction) {
+	t.Helper()
+	v.DirectionC <- d
+}
+
+func (v *ViewSpy) GetStatus(t testing.TB) snake.Status {
+	t.Helper()
+	return <-v.StatusC
+}
+
+func (v *ViewSpy) GetSnakeCoordinates(t testing.TB) *[]snake.Coordinate {
+	t.Helper()
+	return <-v.SnakeCoordinatesC
+}
+
 func (v *ViewSpy) GetFoodCoordinate(t testing.TB) *snake.Coordinate {
 	t.Helper()
-	select {
-	case f := <-v.FoodCoordinateC:
-		return f
-	case <-time.After(time.Millisecond * 5):
-		t.Error("should have received food coordinate from view")
-		return nil
+	return <-v.FoodCoordinateC
+}
+
+// startPlaying selects the Play item of the title menu.
+func startPlaying(t testing.TB, view *ViewSpy) {
+	t.Helper()
+	menu := view.GetMenu(t)
+	if menu.Item() != snake.PlayItem {
+		t.Fatalf("got %q selected item, want %q", menu.Item(), snake.PlayItem)
+	}
+	view.SendSelect(t)
+}
+
+func assertMenu(t testing.TB, got snake.Menu, title, item string) {
+	t.Helper()
+	if got.Title != title || got.Item() != item {
+		t.Errorf("got menu %q with %q selected, want menu %q with %q selected", got.Title, got.Item(), title, item)
+	}
+}
+
+func assertStatus(t testing.TB, got snake.Status, score, length int) {
+	t.Helper()
+	if got.Score != score || got.Length != length {
+		t.Errorf("got score %d and length %d, want score %d and length %d", got.Score, got.Length, score, length)
+	}
+}
+
+func assertSize(t testing.TB, got, want snake.Size) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got size %v, want %v", got, want)
 	}
 }
 
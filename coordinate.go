@@ -14,3 +14,25 @@ func contains(arr []Coordinate, c Coordinate) bool {
 	}
 	return false
 }
+
+// direction returns the Direction to move from the from coordinate to reach
+// the adjacent to coordinate. It returns 0 if the coordinates are not adjacent.
+func direction(from, to Coordinate) Direction {
+	switch {
+	case to.X == from.X && to.Y == from.Y-1:
+		return Up
+	case to.X == from.X && to.Y == from.Y+1:
+		return Down
+	case to.Y == from.Y && to.X == from.X-1:
+		return Left
+	case to.Y == from.Y && to.X == from.X+1:
+		return Right
+	}
+	return 0
+}
+
+// Size implements board dimensions.
+type Size struct {
+	Width  int
+	Height int
+}
@@ -25,3 +25,18 @@ func (d Direction) String() string {
 	}
 	return "Invalid direction"
 }
+
+// opposite returns the direction opposite to d, or 0 if d is not a direction.
+func opposite(d Direction) Direction {
+	switch d {
+	case Up:
+		return Down
+	case Down:
+		return Up
+	case Left:
+		return Right
+	case Right:
+		return Left
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package snake
+
+const (
+	ErrGameOver         = EngineError("snake: engine: game over, reset to play again")
+	ErrInvalidBoardSize = EngineError("snake: engine: invalid board size")
+)
+
+// MinEngineWidth and MinEngineHeight are the smallest board on which
+// a snake of the default length starts with room for the food.
+const (
+	MinEngineWidth  = 6
+	MinEngineHeight = 2
+)
+
+// EngineError type defines engine errors
+type EngineError string
+
+func (e EngineError) Error() string {
+	return string(e)
+}
+
+// Engine plays a snake game one step at a time, without a clock:
+// each Step moves the snake once. It plays a headless Game, which is
+// never started, so the snake moves like on the game clock ticks.
+// It is not safe for concurrent use.
+type Engine struct {
+	game *Game
+	over bool
+	won  bool
+}
+
+// EngineState is a snapshot of an Engine game.
+type EngineState struct {
+	Width  int          `json:"width"`
+	Height int          `json:"height"`
+	Snake  []Coordinate `json:"snake"`
+	Food   Coordinate   `json:"food"`
+	Face   Direction    `json:"face"`
+	Score  int          `json:"score"`
+	Steps  int          `json:"steps"`
+	Over   bool         `json:"over"`
+	Won    bool         `json:"won"`
+}
+
+// NewEngine returns a pointer to Engine and starts a game,
+// generating the first food coordinate. It returns ErrBoardFull
+// if the snake fills the board.
+func NewEngine(snake *Snake, foodProducer FoodGenerator) (*Engine, error) {
+	e := &Engine{game: NewGame(snake, RealClock{}, foodProducer)}
+	if err := e.game.startHeadless(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reset resets the snake and starts a new game.
+func (e *Engine) Reset() error {
+	e.over, e.won = false, false
+	return e.game.startHeadless()
+}
+
+// Step moves the snake towards d, or towards its face direction if d is
+// not a valid move. When the snake eats the food it grows, the score is
+// incremented and a new food is generated. The game is lost when the
+// snake head moves out of the board or hits its body, and is won when
+// the snake fills the board. Step returns true if the snake ate the food,
+// or ErrGameOver if the game is over.
+func (e *Engine) Step(d Direction) (bool, error) {
+	if e.over {
+		return false, ErrGameOver
+	}
+	ate, result := e.game.step(d)
+	if result != nil {
+		e.over, e.won = true, *result
+	}
+	return ate, nil
+}
+
+// State returns a snapshot of the game.
+func (e *Engine) State() EngineState {
+	s := e.game.engineState()
+	s.Over, s.Won = e.over, e.won
+	return s
+}
@@ -0,0 +1,106 @@
+package snake_test
+
+import (
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestEngine(t *testing.T) {
+	t.Run("should move the snake one cell each step", func(t *testing.T) {
+		engine := initEngine(t, snake.NewSnake(10, 10), snake.Coordinate{X: 0, Y: 0})
+
+		ate, err := engine.Step(snake.Up)
+		snake.AssertNoError(t, err)
+
+		got := engine.State()
+		snake.AssertCoordinates(t, got.Snake, []snake.Coordinate{{X: 6, Y: 4}, {X: 6, Y: 5}, {X: 7, Y: 5}})
+		if ate || got.Steps != 1 || got.Over {
+			t.Errorf("got state %+v, want one step without eating", got)
+		}
+	})
+
+	t.Run("should keep the face direction on invalid moves", func(t *testing.T) {
+		engine := initEngine(t, snake.NewSnake(10, 10), snake.Coordinate{X: 0, Y: 0})
+
+		_, err := engine.Step(snake.Right)
+		snake.AssertNoError(t, err)
+
+		snake.AssertCoordinate(t, engine.State().Snake[0], snake.Coordinate{X: 5, Y: 5})
+	})
+
+	t.Run("should grow and score eating food", func(t *testing.T) {
+		engine := initEngine(t, snake.NewSnake(10, 10), snake.Coordinate{X: 5, Y: 5}, snake.Coordinate{X: 0, Y: 0})
+
+		ate, err := engine.Step(snake.Left)
+		snake.AssertNoError(t, err)
+
+		got := engine.State()
+		if !ate || got.Score != 1 || len(got.Snake) != 4 {
+			t.Errorf("got state %+v, want the snake to eat", got)
+		}
+		snake.AssertCoordinate(t, got.Food, snake.Coordinate{X: 0, Y: 0})
+	})
+
+	t.Run("should lose moving out of the board and refuse further steps", func(t *testing.T) {
+		engine := initEngine(t, snake.NewSnake(10, 10), snake.Coordinate{X: 0, Y: 0})
+
+		for i := 0; i < 7; i++ {
+			_, err := engine.Step(snake.Left)
+			snake.AssertNoError(t, err)
+		}
+
+		got := engine.State()
+		if !got.Over || got.Won {
+			t.Errorf("got state %+v, want a lost game", got)
+		}
+		_, err := engine.Step(snake.Left)
+		snake.AssertError(t, err, snake.ErrGameOver)
+	})
+
+	t.Run("should win when the snake fills the board", func(t *testing.T) {
+		f := &snake.FoodStub{}
+		f.Seed([]snake.FoodStubValue{
+			{Coord: snake.Coordinate{X: 0, Y: 0}},
+			{Err: snake.ErrBoardFull},
+		})
+		engine, err := snake.NewEngine(snake.NewSnakeOfLength(3, 1, 2), f)
+		snake.AssertNoError(t, err)
+
+		ate, err := engine.Step(snake.Left)
+		snake.AssertNoError(t, err)
+
+		got := engine.State()
+		if !ate || !got.Over || !got.Won {
+			t.Errorf("got state %+v, want a won game", got)
+		}
+	})
+
+	t.Run("should reset the game", func(t *testing.T) {
+		engine := initEngine(t, snake.NewSnake(10, 10), snake.Coordinate{X: 0, Y: 0}, snake.Coordinate{X: 1, Y: 1})
+		engine.Step(snake.Up)
+
+		err := engine.Reset()
+		snake.AssertNoError(t, err)
+
+		got := engine.State()
+		snake.AssertCoordinates(t, got.Snake, []snake.Coordinate{{X: 6, Y: 5}, {X: 7, Y: 5}, {X: 8, Y: 5}})
+		snake.AssertCoordinate(t, got.Food, snake.Coordinate{X: 1, Y: 1})
+		if got.Steps != 0 || got.Score != 0 || got.Over {
+			t.Errorf("got state %+v, want a new game", got)
+		}
+	})
+}
+
+func initEngine(t testing.TB, s *snake.Snake, foods ...snake.Coordinate) *snake.Engine {
+	t.Helper()
+	f := &snake.FoodStub{}
+	values := make([]snake.FoodStubValue, len(foods))
+	for i, c := range foods {
+		values[i] = snake.FoodStubValue{Coord: c}
+	}
+	f.Seed(values)
+	engine, err := snake.NewEngine(s, f)
+	snake.AssertNoError(t, err)
+	return engine
+}
@@ -0,0 +1,322 @@
+package snake
+
+import (
+	"math/rand"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const ErrInvalidEntities = SnakeErr("snake: invalid entities")
+
+// Entity runes and colors.
+const (
+	BlockRune   = '■'
+	BlockColor  = tcell.ColorSilver
+	MouseRune   = '●'
+	MouseColor  = tcell.ColorKhaki
+	HazardRune  = '✱'
+	HazardColor = tcell.ColorOrangeRed
+)
+
+// EntityKind defines how an entity moves and what happens
+// when it collides with the snake.
+type EntityKind int
+
+const (
+	// Block patrols back and forth along its path.
+	// The snake loses if its head hits a block.
+	Block EntityKind = iota + 1
+	// Mouse is a food which flees from the snake head.
+	// The snake eats a mouse like the food, then the mouse
+	// respawns on a free cell.
+	Mouse
+	// Hazard moves by its velocity, bouncing off the board sides
+	// and the obstacles. The snake loses if its head hits a hazard.
+	Hazard
+)
+
+func (k EntityKind) String() string {
+	switch k {
+	case Block:
+		return "Block"
+	case Mouse:
+		return "Mouse"
+	case Hazard:
+		return "Hazard"
+	}
+	return "Invalid entity"
+}
+
+// Entity is a board cell which moves on its own every Every ticks, starting
+// from Position. A Block walks the cells of Path, which should be adjacent,
+// from Path[Index] towards the end of the path, or towards its start if
+// Reverse is set, turning around at the path ends. A Hazard moves by
+// Velocity, whose components should be -1, 0 or 1. Eaten is set on the
+// mice which the snake ate on the last tick.
+type Entity struct {
+	Kind     EntityKind   `json:"kind"`
+	Position Coordinate   `json:"position"`
+	Every    int          `json:"every"`
+	Path     []Coordinate `json:"path,omitempty"`
+	Index    int          `json:"index,omitempty"`
+	Reverse  bool         `json:"reverse,omitempty"`
+	Velocity Coordinate   `json:"velocity"`
+	Eaten    bool         `json:"-"`
+}
+
+// entityGlyph returns the rune and the color drawing the entities of kind.
+func entityGlyph(kind EntityKind) (rune, tcell.Color, bool) {
+	switch kind {
+	case Block:
+		return BlockRune, BlockColor, true
+	case Mouse:
+		return MouseRune, MouseColor, true
+	case Hazard:
+		return HazardRune, HazardColor, true
+	}
+	return 0, 0, false
+}
+
+// harmful reports whether the snake loses when its head hits e.
+func (e Entity) harmful() bool {
+	return e.Kind != Mouse
+}
+
+// movesOn reports whether e moves on the tick.
+func (e Entity) movesOn(tick int) bool {
+	return e.Every <= 1 || tick%e.Every == 0
+}
+
+// entityAt returns the index of the entity on c, or -1.
+func entityAt(entities []Entity, c Coordinate) int {
+	for i, e := range entities {
+		if e.Position == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// entityCells returns the positions of entities.
+func entityCells(entities []Entity) []Coordinate {
+	cells := make([]Coordinate, len(entities))
+	for i, e := range entities {
+		cells[i] = e.Position
+	}
+	return cells
+}
+
+// copyEntities returns a deep copy of entities, so that the copy
+// can be sent while the entities move.
+func copyEntities(entities []Entity) []Entity {
+	if entities == nil {
+		return nil
+	}
+	c := make([]Entity, len(entities))
+	for i, e := range entities {
+		c[i] = e
+		c[i].Path = append([]Coordinate(nil), e.Path...)
+	}
+	return c
+}
+
+// validateEntities returns ErrInvalidEntities if an entity is outside the
+// width x height board, shares its cell with another entity or is one of the
+// occupied coordinates, if a block path is not a chain of adjacent cells
+// inside the board with the block on Path[Index], if a hazard velocity
+// component is not -1, 0 or 1, or if the kind is unknown.
+func validateEntities(entities []Entity, width, height int, occupied []Coordinate) error {
+	inside := func(c Coordinate) bool {
+		return c.X >= 0 && c.X < width && c.Y >= 0 && c.Y < height
+	}
+	for i, e := range entities {
+		if !inside(e.Position) || contains(occupied, e.Position) || entityAt(entities[:i], e.Position) >= 0 || e.Every < 0 {
+			return ErrInvalidEntities
+		}
+		switch e.Kind {
+		case Block:
+			if e.Index < 0 || e.Index >= len(e.Path) || e.Path[e.Index] != e.Position {
+				return ErrInvalidEntities
+			}
+			for j, c := range e.Path {
+				if !inside(c) || j > 0 && direction(e.Path[j-1], c) == 0 {
+					return ErrInvalidEntities
+				}
+			}
+		case Hazard:
+			if absInt(e.Velocity.X) > 1 || absInt(e.Velocity.Y) > 1 {
+				return ErrInvalidEntities
+			}
+		case Mouse:
+		default:
+			return ErrInvalidEntities
+		}
+	}
+	return nil
+}
+
+// board is the state the entities move on: the bounds inside the walls,
+// the snake coordinates, with the head first, and the cells the entities
+// can not move on.
+type board struct {
+	bounds    Bounds
+	snake     []Coordinate
+	obstacles []Coordinate
+}
+
+func (b board) inside(c Coordinate) bool {
+	return b.bounds.Contains(c)
+}
+
+// free reports whether an entity can move on c, which is free
+// if it is inside the board and not on the snake, on an obstacle
+// or on one of entities.
+func (b board) free(c Coordinate, entities []Entity) bool {
+	return b.inside(c) && !contains(b.snake, c) && !contains(b.obstacles, c) && entityAt(entities, c) < 0
+}
+
+// moveEntities moves the entities which move on the tick. Blocks and
+// hazards moving on the snake head return true, as the game is lost.
+// An entity which can not move stays on its cell: blocks wait for their
+// next path cell to be free, hazards bounce back.
+func moveEntities(entities []Entity, tick int, b board) bool {
+	for i := range entities {
+		e := &entities[i]
+		if !e.movesOn(tick) {
+			continue
+		}
+		switch e.Kind {
+		case Block:
+			if moveBlock(e, entities, b) {
+				return true
+			}
+		case Mouse:
+			moveMouse(e, entities, b)
+		case Hazard:
+			if moveHazard(e, entities, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// moveBlock moves the block to its next path cell, if free.
+// It returns true if the cell is the snake head.
+func moveBlock(e *Entity, entities []Entity, b board) bool {
+	if len(e.Path) < 2 {
+		return false
+	}
+	next := e.Index + 1
+	if e.Reverse {
+		next = e.Index - 1
+	}
+	if next < 0 || next >= len(e.Path) {
+		e.Reverse = !e.Reverse
+		next = 2*e.Index - next
+	}
+	c := e.Path[next]
+	if len(b.snake) > 0 && c == b.snake[0] {
+		return true
+	}
+	if b.free(c, entities) {
+		e.Index, e.Position = next, c
+	}
+	return false
+}
+
+// moveMouse moves the mouse to the free adjacent cell farthest from the
+// snake head, staying on its cell if no adjacent cell is farther.
+func moveMouse(e *Entity, entities []Entity, b board) {
+	if len(b.snake) == 0 {
+		return
+	}
+	head := b.snake[0]
+	best, distance := e.Position, manhattan(e.Position, head)
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		c := step(e.Position, d)
+		if b.free(c, entities) && manhattan(c, head) > distance {
+			best, distance = c, manhattan(c, head)
+		}
+	}
+	e.Position = best
+}
+
+// moveHazard moves the hazard by its velocity, reversing the velocity
+// components which would move it off the board or on an obstacle.
+// It returns true if the hazard moves on the snake head.
+func moveHazard(e *Entity, entities []Entity, b board) bool {
+	v := e.Velocity
+	if v == (Coordinate{}) {
+		return false
+	}
+	for _, bounce := range []Coordinate{{1, 1}, {-1, 1}, {1, -1}, {-1, -1}} {
+		velocity := Coordinate{v.X * bounce.X, v.Y * bounce.Y}
+		c := Coordinate{e.Position.X + velocity.X, e.Position.Y + velocity.Y}
+		if len(b.snake) > 0 && c == b.snake[0] {
+			return true
+		}
+		if b.free(c, entities) {
+			e.Position, e.Velocity = c, velocity
+			return false
+		}
+	}
+	return false
+}
+
+// RandomEntities returns n entities of kind placed at random with r on
+// a width x height board, off the occupied coordinates: blocks patrol a
+// straight path of up to 5 cells and move every 2 ticks, mice move every
+// 2 ticks, and hazards move diagonally every 3 ticks. It returns
+// ErrInvalidEntities if the entities do not fit the board.
+func RandomEntities(kind EntityKind, n, width, height int, occupied []Coordinate, r *rand.Rand) ([]Entity, error) {
+	if width < 1 || height < 1 {
+		if n == 0 {
+			return nil, nil
+		}
+		return nil, ErrInvalidEntities
+	}
+	entities := make([]Entity, 0, n)
+	reserved := append([]Coordinate{}, occupied...)
+	for draws := 0; len(entities) < n; draws++ {
+		if draws == n*maxRandomDraws {
+			return nil, ErrInvalidEntities
+		}
+		start := Coordinate{r.Intn(width), r.Intn(height)}
+		e := Entity{Kind: kind, Position: start}
+		switch kind {
+		case Block:
+			d := []Direction{Down, Right}[r.Intn(2)]
+			e.Every, e.Path = 2, []Coordinate{start}
+			for c := step(start, d); len(e.Path) < 5 && c.X < width && c.Y < height; c = step(c, d) {
+				e.Path = append(e.Path, c)
+			}
+		case Mouse:
+			e.Every = 2
+		case Hazard:
+			e.Every = 3
+			e.Velocity = Coordinate{2*r.Intn(2) - 1, 2*r.Intn(2) - 1}
+		default:
+			return nil, ErrInvalidEntities
+		}
+		cells := e.Path
+		if cells == nil {
+			cells = []Coordinate{start}
+		}
+		if !crosses(cells, reserved) && validateEntities(append(entities, e), width, height, occupied) == nil {
+			entities = append(entities, e)
+			reserved = append(append(reserved, start), e.Path...)
+		}
+	}
+	return entities, nil
+}
+
+// crosses reports whether one of the a coordinates is one of the b coordinates.
+func crosses(a, b []Coordinate) bool {
+	for _, c := range a {
+		if contains(b, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package snake_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestEntity(t *testing.T) {
+	width, height := 60, 60
+	newFood := func(c ...snake.Coordinate) *snake.FoodStub {
+		fs := &snake.FoodStub{}
+		values := []snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}}
+		for _, coordinate := range c {
+			values = append(values, snake.FoodStubValue{coordinate, nil})
+		}
+		fs.Seed(values)
+		return fs
+	}
+	// startGame starts a game with entities, skipping the first entities,
+	// snake coordinates and food coordinate sent.
+	startGame := func(t *testing.T, food snake.FoodGenerator, entities ...snake.Entity) (*snake.Game, *snake.FakeClock) {
+		t.Helper()
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, food)
+		t.Cleanup(g.Quit)
+		err := g.SetEntities(entities)
+		snake.AssertNoError(t, err)
+		g.Start(time.Microsecond)
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		return g, clock
+	}
+
+	t.Run("should send the entities before the snake coordinates", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		defer g.Quit()
+		mouse := snake.Entity{Kind: snake.Mouse, Position: snake.Coordinate{10, 10}}
+		g.SetEntities([]snake.Entity{mouse})
+		g.Start(time.Microsecond)
+
+		got := receiveEntities(t, g)
+		assertEntities(t, got, []snake.Entity{mouse})
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertSnakeLength(t, sc, 3)
+	})
+
+	t.Run("block should patrol back and forth along its path", func(t *testing.T) {
+		path := []snake.Coordinate{{10, 10}, {11, 10}, {12, 10}}
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Block, Position: path[0], Every: 1, Path: path})
+
+		for _, want := range []snake.Coordinate{{11, 10}, {12, 10}, {11, 10}, {10, 10}, {11, 10}} {
+			clock.Advance(time.Microsecond)
+			got := receiveEntities(t, g)
+			snake.AssertCoordinate(t, got[0].Position, want)
+			snake.WaitAndReceiveGameChannels(t, g)
+		}
+	})
+
+	t.Run("entities should move every Every ticks", func(t *testing.T) {
+		path := []snake.Coordinate{{10, 10}, {11, 10}}
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Block, Position: path[0], Every: 2, Path: path})
+
+		for _, want := range []snake.Coordinate{{10, 10}, {11, 10}, {11, 10}, {10, 10}} {
+			clock.Advance(time.Microsecond)
+			got := receiveEntities(t, g)
+			snake.AssertCoordinate(t, got[0].Position, want)
+			snake.WaitAndReceiveGameChannels(t, g)
+		}
+	})
+
+	t.Run("snake should lose when its head hits a block", func(t *testing.T) {
+		path := []snake.Coordinate{{35, 30}, {35, 29}}
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Block, Position: path[0], Every: 10, Path: path})
+
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, false)
+	})
+
+	t.Run("snake should lose when a hazard moves on its head", func(t *testing.T) {
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Hazard, Position: snake.Coordinate{34, 29}, Every: 1, Velocity: snake.Coordinate{1, 1}})
+
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.AssertCoordinate(t, sc[0], snake.Coordinate{35, 30})
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, false)
+	})
+
+	t.Run("hazard should bounce off the board sides", func(t *testing.T) {
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Hazard, Position: snake.Coordinate{0, 0}, Every: 1, Velocity: snake.Coordinate{-1, -1}})
+
+		clock.Advance(time.Microsecond)
+		got := receiveEntities(t, g)
+		snake.AssertCoordinate(t, got[0].Position, snake.Coordinate{1, 1})
+		snake.AssertCoordinate(t, got[0].Velocity, snake.Coordinate{1, 1})
+	})
+
+	t.Run("snake should eat mice, growing and scoring", func(t *testing.T) {
+		g, clock := startGame(t, newFood(snake.Coordinate{5, 5}), snake.Entity{Kind: snake.Mouse, Position: snake.Coordinate{34, 30}, Every: 100})
+
+		clock.Advance(time.Microsecond)
+		got := receiveEntities(t, g)
+		if got[0].Eaten {
+			t.Error("mouse should not be eaten yet")
+		}
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		got = receiveEntities(t, g)
+		if !got[0].Eaten {
+			t.Error("mouse should be eaten")
+		}
+		snake.AssertCoordinate(t, got[0].Position, snake.Coordinate{5, 5})
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertSnakeLength(t, sc, 4)
+		if score := g.Save().Score; score != 1 {
+			t.Errorf("got score %d, want 1", score)
+		}
+	})
+
+	t.Run("mouse should flee from the snake head", func(t *testing.T) {
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Mouse, Position: snake.Coordinate{30, 30}, Every: 1})
+
+		clock.Advance(time.Microsecond)
+		got := receiveEntities(t, g)
+		snake.AssertCoordinate(t, got[0].Position, snake.Coordinate{30, 29})
+	})
+
+	t.Run("should restart with the entities on their start cells", func(t *testing.T) {
+		path := []snake.Coordinate{{10, 10}, {11, 10}}
+		g, clock := startGame(t, newFood(snake.Coordinate{0, 1}), snake.Entity{Kind: snake.Block, Position: path[0], Every: 1, Path: path})
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		g.Restart(time.Microsecond)
+
+		got := receiveEntities(t, g)
+		snake.AssertCoordinate(t, got[0].Position, path[0])
+	})
+
+	t.Run("should not set invalid entities", func(t *testing.T) {
+		cases := map[string]snake.Entity{
+			"out of board":    {Kind: snake.Mouse, Position: snake.Coordinate{60, 0}},
+			"on the snake":    {Kind: snake.Mouse, Position: snake.Coordinate{36, 30}},
+			"off its path":    {Kind: snake.Block, Position: snake.Coordinate{1, 1}, Path: []snake.Coordinate{{2, 1}, {3, 1}}},
+			"broken path":     {Kind: snake.Block, Position: snake.Coordinate{1, 1}, Path: []snake.Coordinate{{1, 1}, {3, 1}}},
+			"fast hazard":     {Kind: snake.Hazard, Position: snake.Coordinate{1, 1}, Velocity: snake.Coordinate{2, 0}},
+			"unknown kind":    {Position: snake.Coordinate{1, 1}},
+			"negative period": {Kind: snake.Mouse, Position: snake.Coordinate{1, 1}, Every: -1},
+		}
+		for name, e := range cases {
+			g := snake.NewGame(snake.NewSnake(width, height), snake.NewFakeClock(time.Now()), newFood())
+			err := g.SetEntities([]snake.Entity{e})
+			if err != snake.ErrInvalidEntities {
+				t.Errorf("got error %v setting entity %s, want %v", err, name, snake.ErrInvalidEntities)
+			}
+		}
+		g := snake.NewGame(snake.NewSnake(width, height), snake.NewFakeClock(time.Now()), newFood())
+		mouse := snake.Entity{Kind: snake.Mouse, Position: snake.Coordinate{1, 1}}
+		err := g.SetEntities([]snake.Entity{mouse, mouse})
+		snake.AssertError(t, err, snake.ErrInvalidEntities)
+	})
+
+	t.Run("should save and restore the entities", func(t *testing.T) {
+		path := []snake.Coordinate{{10, 10}, {11, 10}}
+		g, clock := startGame(t, newFood(), snake.Entity{Kind: snake.Block, Position: path[0], Every: 1, Path: path})
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+
+		s := g.Save()
+		restored := snake.NewGame(snake.NewSnake(width, height), snake.NewFakeClock(time.Now()), newFood())
+		defer restored.Quit()
+		err := restored.Restore(s)
+		snake.AssertNoError(t, err)
+		restored.Start(time.Microsecond)
+
+		got := receiveEntities(t, restored)
+		assertEntities(t, got, s.Entities)
+		snake.AssertCoordinate(t, got[0].Position, path[1])
+	})
+
+	t.Run("should place random entities off the occupied cells", func(t *testing.T) {
+		s := snake.NewSnake(20, 20)
+		for _, kind := range []snake.EntityKind{snake.Block, snake.Mouse, snake.Hazard} {
+			entities, err := snake.RandomEntities(kind, 4, 20, 20, s.GetCoordinates(), rand.New(rand.NewSource(42)))
+			snake.AssertNoError(t, err)
+			if len(entities) != 4 {
+				t.Fatalf("got %d %v entities, want 4", len(entities), kind)
+			}
+			g := snake.NewGame(snake.NewSnake(20, 20), snake.NewFakeClock(time.Now()), newFood())
+			snake.AssertNoError(t, g.SetEntities(entities))
+		}
+		_, err := snake.RandomEntities(snake.Mouse, 10, 2, 2, nil, rand.New(rand.NewSource(42)))
+		snake.AssertError(t, err, snake.ErrInvalidEntities)
+	})
+}
+
+func receiveEntities(t testing.TB, g *snake.Game) []snake.Entity {
+	t.Helper()
+	return <-g.ReceiveEntities()
+}
+
+func assertEntities(t testing.TB, got, want []snake.Entity) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got entities %+v, want %+v", got, want)
+	}
+}
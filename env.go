@@ -0,0 +1,356 @@
+package snake
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Observation is an encoded game state: Data holds the values
+// of a row major tensor of the given Shape.
+type Observation struct {
+	Shape []int
+	Data  []float64
+}
+
+// Encoder encodes the game state in the observations returned by an Env.
+type Encoder interface {
+	// Encode should encode s in an observation. Observations of states with
+	// the same board size should have the same shape.
+	Encode(s EngineState) Observation
+}
+
+// RewardShaper computes the rewards returned by an Env.
+type RewardShaper interface {
+	// Reward should return the reward of a step from the prev to the next state.
+	Reward(prev, next EngineState) float64
+}
+
+// Info holds the information about an Env step not encoded in the observation.
+type Info struct {
+	Score     int
+	Steps     int
+	Won       bool
+	Truncated bool
+}
+
+// Env is a reinforcement learning environment on top of an Engine:
+// each episode is a game, each Step moves the snake once.
+// It is not safe for concurrent use.
+type Env struct {
+	width    int
+	height   int
+	encoder  Encoder
+	rewards  RewardShaper
+	maxSteps int
+	engine   *Engine
+	state    EngineState
+}
+
+// NewEnv returns a pointer to Env playing on a board of width and height
+// cells, encoding the observations with encoder and computing the rewards
+// with rewards. It returns ErrInvalidBoardSize if the snake does not fit the board.
+// Reset should be called to start the first episode, otherwise the first
+// Step starts it like Reset(0).
+func NewEnv(width, height int, encoder Encoder, rewards RewardShaper) (*Env, error) {
+	if width < MinEngineWidth || height < MinEngineHeight {
+		return nil, ErrInvalidBoardSize
+	}
+	return &Env{width: width, height: height, encoder: encoder, rewards: rewards}, nil
+}
+
+// SetMaxSteps truncates the episodes after max steps. Zero means no limit.
+func (e *Env) SetMaxSteps(max int) {
+	e.maxSteps = max
+}
+
+// Reset starts a new episode, generating the food with seed,
+// and returns its first observation.
+func (e *Env) Reset(seed int64) Observation {
+	engine, err := NewEngine(NewSnake(e.width, e.height), NewSeededFood(e.width, e.height, seed))
+	if err != nil {
+		// NewEnv checked that the snake leaves room for the food
+		panic(err)
+	}
+	e.engine = engine
+	e.state = engine.State()
+	return e.encoder.Encode(e.state)
+}
+
+// Step moves the snake towards d and returns the observation, the reward,
+// true if the episode is done and the step information. The episode is done
+// when the game is over or when it is truncated after the max steps.
+// Stepping a done episode does not move the snake and returns the last
+// observation with a zero reward until Reset is called.
+func (e *Env) Step(d Direction) (Observation, float64, bool, Info) {
+	if e.engine == nil {
+		e.Reset(0)
+	}
+	if info := e.info(); e.state.Over || info.Truncated {
+		return e.encoder.Encode(e.state), 0, true, info
+	}
+	prev := e.state
+	if _, err := e.engine.Step(d); err != nil {
+		// the episode is not done, so the game is not over
+		panic(err)
+	}
+	e.state = e.engine.State()
+	info := e.info()
+	return e.encoder.Encode(e.state), e.rewards.Reward(prev, e.state), e.state.Over || info.Truncated, info
+}
+
+func (e *Env) info() Info {
+	return Info{
+		Score:     e.state.Score,
+		Steps:     e.state.Steps,
+		Won:       e.state.Won,
+		Truncated: !e.state.Over && e.maxSteps > 0 && e.state.Steps >= e.maxSteps,
+	}
+}
+
+// Rewards is a RewardShaper which rewards eating the food, winning and
+// losing the game, each step, and each step which moves the snake head
+// closer to (or farther from) the food.
+type Rewards struct {
+	Food     float64
+	Win      float64
+	Lose     float64
+	Step     float64
+	Approach float64
+}
+
+// DefaultRewards rewards eating the food and winning, and punishes losing.
+var DefaultRewards = Rewards{Food: 1, Win: 10, Lose: -1}
+
+// Reward returns the reward of a step from the prev to the next state.
+func (r Rewards) Reward(prev, next EngineState) float64 {
+	reward := r.Step
+	switch {
+	case next.Won:
+		reward += r.Food + r.Win
+	case next.Over:
+		reward += r.Lose
+	case next.Score > prev.Score:
+		reward += r.Food
+	default:
+		before := manhattan(prev.Snake[0], prev.Food)
+		after := manhattan(next.Snake[0], next.Food)
+		if after < before {
+			reward += r.Approach
+		} else if after > before {
+			reward -= r.Approach
+		}
+	}
+	return reward
+}
+
+// Grid channels of the GridEncoder observations.
+const (
+	HeadChannel = iota
+	BodyChannel
+	FoodChannel
+)
+
+// GridEncoder encodes the whole board in a [3, height, width] tensor,
+// with the HeadChannel, BodyChannel and FoodChannel cells set to 1.
+type GridEncoder struct{}
+
+// Encode encodes s in a board tensor.
+func (GridEncoder) Encode(s EngineState) Observation {
+	area := s.Width * s.Height
+	data := make([]float64, 3*area)
+	for i, c := range s.Snake {
+		channel := BodyChannel
+		if i == 0 {
+			channel = HeadChannel
+		}
+		data[channel*area+c.Y*s.Width+c.X] = 1
+	}
+	if !s.Won {
+		data[FoodChannel*area+s.Food.Y*s.Width+s.Food.X] = 1
+	}
+	return Observation{[]int{3, s.Height, s.Width}, data}
+}
+
+// Window channels of the WindowEncoder observations.
+const (
+	ObstacleChannel = iota
+	WindowFoodChannel
+)
+
+// WindowEncoder encodes the cells within Radius of the snake head in a
+// [2, 2*Radius+1, 2*Radius+1] tensor, rotated so that the snake faces up.
+// The ObstacleChannel cells are set to 1 on the snake body and outside the
+// board, the WindowFoodChannel cell is set to 1 on the food.
+type WindowEncoder struct {
+	Radius int
+}
+
+// Encode encodes the s cells around the snake head.
+func (w WindowEncoder) Encode(s EngineState) Observation {
+	size := 2*w.Radius + 1
+	area := size * size
+	data := make([]float64, 2*area)
+	head := s.Snake[0]
+	forward, right := faceVectors(s.Face)
+	for wy := -w.Radius; wy <= w.Radius; wy++ {
+		for wx := -w.Radius; wx <= w.Radius; wx++ {
+			c := Coordinate{
+				head.X + right.X*wx - forward.X*wy,
+				head.Y + right.Y*wx - forward.Y*wy,
+			}
+			i := (wy+w.Radius)*size + wx + w.Radius
+			if c.X < 0 || c.X >= s.Width || c.Y < 0 || c.Y >= s.Height || contains(s.Snake[1:], c) {
+				data[ObstacleChannel*area+i] = 1
+			}
+			if c == s.Food && !s.Won {
+				data[WindowFoodChannel*area+i] = 1
+			}
+		}
+	}
+	return Observation{[]int{2, size, size}, data}
+}
+
+// FeatureLen is the length of the FeatureEncoder observations.
+const FeatureLen = 11
+
+// FeatureEncoder encodes the game in a vector of FeatureLen distances and flags:
+// the free cells from the head to the first obstacle towards Up, Down, Left
+// and Right, divided by the board size on that axis; the food horizontal and
+// vertical distances from the head, divided by the board size; the Up, Down,
+// Left and Right face flags; the snake length divided by the board area.
+type FeatureEncoder struct{}
+
+// Encode encodes s in a feature vector.
+func (FeatureEncoder) Encode(s EngineState) Observation {
+	data := make([]float64, 0, FeatureLen)
+	head := s.Snake[0]
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		size := s.Width
+		if Has(d, upDown) {
+			size = s.Height
+		}
+		data = append(data, float64(freeCells(s, head, d))/float64(size))
+	}
+	data = append(data,
+		float64(s.Food.X-head.X)/float64(s.Width),
+		float64(s.Food.Y-head.Y)/float64(s.Height))
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		flag := 0.0
+		if s.Face == d {
+			flag = 1
+		}
+		data = append(data, flag)
+	}
+	data = append(data, float64(len(s.Snake))/float64(s.Width*s.Height))
+	return Observation{[]int{FeatureLen}, data}
+}
+
+// freeCells returns the number of cells from c towards d
+// before the board side or the snake body.
+func freeCells(s EngineState, c Coordinate, d Direction) int {
+	forward, _ := faceVectors(d)
+	n := 0
+	for {
+		c = Coordinate{c.X + forward.X, c.Y + forward.Y}
+		if c.X < 0 || c.X >= s.Width || c.Y < 0 || c.Y >= s.Height || contains(s.Snake, c) {
+			return n
+		}
+		n++
+	}
+}
+
+// faceVectors returns the unit vectors pointing forward
+// and to the right of a snake facing face.
+func faceVectors(face Direction) (forward, right Coordinate) {
+	switch face {
+	case Down:
+		return Coordinate{0, 1}, Coordinate{-1, 0}
+	case Left:
+		return Coordinate{-1, 0}, Coordinate{0, -1}
+	case Right:
+		return Coordinate{1, 0}, Coordinate{0, 1}
+	}
+	return Coordinate{0, -1}, Coordinate{1, 0}
+}
+
+func manhattan(a, b Coordinate) int {
+	return absInt(a.X-b.X) + absInt(a.Y-b.Y)
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// VecEnv steps a batch of environments in parallel. Done episodes are reset
+// automatically on the next seed, so each Step returns the first observation
+// of a new episode in place of the last observation of a done one.
+type VecEnv struct {
+	envs  []*Env
+	seeds []int64
+}
+
+// NewVecEnv returns a pointer to VecEnv stepping envs.
+func NewVecEnv(envs ...*Env) *VecEnv {
+	return &VecEnv{envs, make([]int64, len(envs))}
+}
+
+// Len returns the number of environments.
+func (v *VecEnv) Len() int {
+	return len(v.envs)
+}
+
+// Reset resets the environment i with seed plus i, and returns
+// the first observations.
+func (v *VecEnv) Reset(seed int64) []Observation {
+	observations := make([]Observation, len(v.envs))
+	v.parallel(func(i int) {
+		v.seeds[i] = seed + int64(i)
+		observations[i] = v.envs[i].Reset(v.seeds[i])
+	})
+	return observations
+}
+
+// Step steps the environment i towards directions[i], and returns the
+// observations, rewards, done flags and information of each environment.
+// The done environments are reset on their seed plus the number
+// of environments. It panics if the directions are not one per environment.
+func (v *VecEnv) Step(directions []Direction) ([]Observation, []float64, []bool, []Info) {
+	if len(directions) != len(v.envs) {
+		panic("snake: vec env: directions length does not match the environments")
+	}
+	observations := make([]Observation, len(v.envs))
+	rewards := make([]float64, len(v.envs))
+	dones := make([]bool, len(v.envs))
+	infos := make([]Info, len(v.envs))
+	v.parallel(func(i int) {
+		observations[i], rewards[i], dones[i], infos[i] = v.envs[i].Step(directions[i])
+		if dones[i] {
+			v.seeds[i] += int64(len(v.envs))
+			observations[i] = v.envs[i].Reset(v.seeds[i])
+		}
+	})
+	return observations, rewards, dones, infos
+}
+
+// parallel calls f for each environment index,
+// splitting the environments between GOMAXPROCS go routines.
+func (v *VecEnv) parallel(f func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(v.envs) {
+		workers = len(v.envs)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(v.envs); i += workers {
+				f(i)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+package snake_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestEnv(t *testing.T) {
+	state := snake.EngineState{
+		Width:  6,
+		Height: 4,
+		Snake:  []snake.Coordinate{{X: 3, Y: 2}, {X: 4, Y: 2}, {X: 5, Y: 2}},
+		Food:   snake.Coordinate{X: 1, Y: 0},
+		Face:   snake.Left,
+	}
+
+	t.Run("should return the same observations on the same seed", func(t *testing.T) {
+		env := initEnv(t, snake.GridEncoder{}, snake.DefaultRewards)
+
+		first := env.Reset(3)
+		env.Step(snake.Up)
+		second := env.Reset(3)
+
+		if !reflect.DeepEqual(first, second) {
+			t.Error("got different observations resetting with the same seed")
+		}
+	})
+
+	t.Run("should punish losing and end the episode", func(t *testing.T) {
+		env := initEnv(t, snake.GridEncoder{}, snake.DefaultRewards)
+		env.Reset(1)
+
+		var reward float64
+		done := false
+		for i := 0; i < 10 && !done; i++ {
+			_, reward, done, _ = env.Step(snake.Up)
+		}
+
+		if !done || reward != snake.DefaultRewards.Lose {
+			t.Errorf("got reward %v and done %v, want %v and done", reward, done, snake.DefaultRewards.Lose)
+		}
+	})
+
+	t.Run("should truncate the episode after the max steps", func(t *testing.T) {
+		env := initEnv(t, snake.GridEncoder{}, snake.DefaultRewards)
+		env.SetMaxSteps(2)
+		env.Reset(1)
+
+		_, _, done, _ := env.Step(snake.Left)
+		if done {
+			t.Fatal("should not end the episode before the max steps")
+		}
+		_, _, done, info := env.Step(snake.Up)
+		if !done || !info.Truncated || info.Steps != 2 {
+			t.Errorf("got done %v and info %+v, want a truncated episode", done, info)
+		}
+		_, reward, done, info := env.Step(snake.Up)
+		if !done || reward != 0 || info.Steps != 2 {
+			t.Errorf("got reward %v, done %v and info %+v stepping a truncated episode, want it not stepped", reward, done, info)
+		}
+	})
+
+	t.Run("should start the first episode on the first step", func(t *testing.T) {
+		env := initEnv(t, snake.GridEncoder{}, snake.DefaultRewards)
+
+		_, _, done, info := env.Step(snake.Left)
+
+		if done || info.Steps != 1 {
+			t.Errorf("got done %v and info %+v, want the first step of an episode", done, info)
+		}
+	})
+
+	t.Run("should shape rewards", func(t *testing.T) {
+		rewards := snake.Rewards{Food: 1, Win: 5, Lose: -2, Step: -0.25, Approach: 0.5}
+		closer := state
+		closer.Snake = []snake.Coordinate{{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 4, Y: 2}}
+		ate := closer
+		ate.Score = 1
+		lost := closer
+		lost.Over = true
+		won := ate
+		won.Over, won.Won = true, true
+
+		cases := []struct {
+			name string
+			next snake.EngineState
+			want float64
+		}{
+			{"approaching the food", closer, -0.25 + 0.5},
+			{"eating the food", ate, -0.25 + 1},
+			{"losing", lost, -0.25 - 2},
+			{"winning", won, -0.25 + 1 + 5},
+		}
+		for _, c := range cases {
+			if got := rewards.Reward(state, c.next); got != c.want {
+				t.Errorf("got reward %v %s, want %v", got, c.name, c.want)
+			}
+		}
+		if got := rewards.Reward(closer, state); got != -0.25-0.5 {
+			t.Errorf("got reward %v moving away from the food, want %v", got, -0.25-0.5)
+		}
+	})
+
+	t.Run("should encode the board in a grid", func(t *testing.T) {
+		got := snake.GridEncoder{}.Encode(state)
+
+		if !reflect.DeepEqual(got.Shape, []int{3, 4, 6}) {
+			t.Fatalf("got shape %v, want [3 4 6]", got.Shape)
+		}
+		area := 24
+		assertObservationValue(t, got, snake.HeadChannel*area+2*6+3, 1)
+		assertObservationValue(t, got, snake.BodyChannel*area+2*6+4, 1)
+		assertObservationValue(t, got, snake.BodyChannel*area+2*6+3, 0)
+		assertObservationValue(t, got, snake.FoodChannel*area+0*6+1, 1)
+	})
+
+	t.Run("should encode a window rotated to the snake face", func(t *testing.T) {
+		got := snake.WindowEncoder{Radius: 1}.Encode(state)
+
+		if !reflect.DeepEqual(got.Shape, []int{2, 3, 3}) {
+			t.Fatalf("got shape %v, want [2 3 3]", got.Shape)
+		}
+		// facing left, the body is behind the head, below the window center
+		want := []float64{
+			0, 0, 0,
+			0, 0, 0,
+			0, 1, 0,
+		}
+		if !reflect.DeepEqual(got.Data[:9], want) {
+			t.Errorf("got obstacles %v, want %v", got.Data[:9], want)
+		}
+
+		atWall := state
+		atWall.Snake = []snake.Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}
+		got = snake.WindowEncoder{Radius: 1}.Encode(atWall)
+		// facing left on the top left corner, the board sides are ahead and to the right
+		want = []float64{
+			1, 1, 1,
+			0, 0, 1,
+			0, 1, 1,
+		}
+		if !reflect.DeepEqual(got.Data[:9], want) {
+			t.Errorf("got obstacles %v, want %v", got.Data[:9], want)
+		}
+		atWall.Food = snake.Coordinate{X: 0, Y: 1}
+		got = snake.WindowEncoder{Radius: 1}.Encode(atWall)
+		// the food is below the head, on the left of a snake facing left
+		assertObservationValue(t, got, 9+1*3+0, 1)
+	})
+
+	t.Run("should encode distances in a feature vector", func(t *testing.T) {
+		got := snake.FeatureEncoder{}.Encode(state)
+
+		want := []float64{
+			2.0 / 4, 1.0 / 4, 3.0 / 6, 0,
+			-2.0 / 6, -2.0 / 4,
+			0, 0, 1, 0,
+			3.0 / 24,
+		}
+		if !reflect.DeepEqual(got.Data, want) || !reflect.DeepEqual(got.Shape, []int{snake.FeatureLen}) {
+			t.Errorf("got features %v, want %v", got.Data, want)
+		}
+	})
+
+	t.Run("should step vectorised environments resetting the done ones", func(t *testing.T) {
+		envs := make([]*snake.Env, 4)
+		for i := range envs {
+			envs[i] = initEnv(t, snake.FeatureEncoder{}, snake.DefaultRewards)
+		}
+		envs[0].SetMaxSteps(1)
+		vec := snake.NewVecEnv(envs...)
+
+		observations := vec.Reset(10)
+		if len(observations) != vec.Len() {
+			t.Fatalf("got %d observations, want %d", len(observations), vec.Len())
+		}
+		if !reflect.DeepEqual(observations[1], envs[1].Reset(11)) {
+			t.Error("should reset the environments with consecutive seeds")
+		}
+		vec.Reset(10)
+
+		observations, _, dones, infos := vec.Step([]snake.Direction{snake.Up, snake.Up, snake.Up, snake.Up})
+
+		if !dones[0] || dones[1] || !infos[0].Truncated {
+			t.Errorf("got dones %v, want only the first environment done", dones)
+		}
+		if !reflect.DeepEqual(observations[0], envs[0].Reset(14)) {
+			t.Error("should reset the done environment on the next seed")
+		}
+	})
+}
+
+func initEnv(t testing.TB, encoder snake.Encoder, rewards snake.RewardShaper) *snake.Env {
+	t.Helper()
+	env, err := snake.NewEnv(10, 10, encoder, rewards)
+	snake.AssertNoError(t, err)
+	return env
+}
+
+func assertObservationValue(t testing.TB, o snake.Observation, i int, want float64) {
+	t.Helper()
+	if o.Data[i] != want {
+		t.Errorf("got value %v at %d, want %v", o.Data[i], i, want)
+	}
+}
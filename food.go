@@ -5,9 +5,7 @@ import (
 	"time"
 )
 
-const (
-	ErrBoardFull = FoodError("snake: food: board full, can not generate food coordinate")
-)
+const ErrBoardFull = FoodError("snake: food: board full, can not generate food coordinate")
 
 // FoodGenerator interface describes a food producer.
 //
@@ -25,29 +23,96 @@ type FoodGenerator interface {
 type Food struct {
 	width  int
 	height int
+	source *splitMixSource
+	rand   *rand.Rand
 }
 
 // NewFood returns a pointer to Food and seeds the generator with current time
 func NewFood(width, height int) *Food {
-	rand.Seed(time.Now().UnixNano())
-	return &Food{width, height}
+	return NewSeededFood(width, height, time.Now().UnixNano())
+}
+
+// NewSeededFood returns a pointer to Food seeding the generator with seed,
+// so that foods with the same seed generate the same coordinates.
+func NewSeededFood(width, height int, seed int64) *Food {
+	source := &splitMixSource{}
+	source.Seed(seed)
+	return &Food{width, height, source, rand.New(source)}
 }
 
 // Generate returns a random coordinate for the food which is not in c Coordinates.
-// If c length is equal to all the available cells in the board it returns ErrBoardFull.
+// If c covers all the cells in the board it returns ErrBoardFull. The
+// coordinates outside the board and the repeated ones are not counted.
 func (f *Food) Generate(c []Coordinate) (Coordinate, error) {
-	if len(c) == f.width*f.height {
+	board := fullBounds(f.width, f.height)
+	taken := make(map[Coordinate]bool, len(c))
+	for _, cell := range c {
+		if board.Contains(cell) {
+			taken[cell] = true
+		}
+	}
+	if len(taken) >= f.width*f.height {
 		return Coordinate{}, ErrBoardFull
 	}
 	var foodCoordinate Coordinate
 	for ok := true; ok; ok = contains(c, foodCoordinate) {
-		w := rand.Intn(f.width)
-		h := rand.Intn(f.height)
+		w := f.rand.Intn(f.width)
+		h := f.rand.Intn(f.height)
 		foodCoordinate = Coordinate{w, h}
 	}
 	return foodCoordinate, nil
 }
 
+// Resize sets the board width and height on which the food is generated.
+func (f *Food) Resize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// RNGState is the state of a Food random generator: the seed
+// and the generator state reached since seeding.
+type RNGState struct {
+	Seed  int64  `json:"seed"`
+	State uint64 `json:"state"`
+}
+
+// RNGState returns the random generator state.
+func (f *Food) RNGState() RNGState {
+	return RNGState{f.source.seed, f.source.state}
+}
+
+// RestoreRNG restores the random generator state s, so that the food
+// generates the same coordinates as the food s was taken from.
+// Every state is valid, so it always returns nil.
+func (f *Food) RestoreRNG(s RNGState) error {
+	f.source.seed, f.source.state = s.Seed, s.State
+	return nil
+}
+
+// splitMixSource is a rand.Source implementing the SplitMix64 generator,
+// whose whole state is a single number, so that it can be saved and
+// restored without drawing values again.
+type splitMixSource struct {
+	seed  int64
+	state uint64
+}
+
+func (s *splitMixSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *splitMixSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *splitMixSource) Seed(seed int64) {
+	s.seed, s.state = seed, uint64(seed)
+}
+
 // FoodError type defines food errors
 type FoodError string
 
@@ -65,4 +65,53 @@ func TestFood(t *testing.T) {
 		_, err := food.Generate(snakeCoordinates)
 		snake.AssertError(t, err, snake.ErrBoardFull)
 	})
+
+	t.Run("should return error when the board is full counting each cell once", func(t *testing.T) {
+		food := snake.NewFood(2, 2)
+		c := []snake.Coordinate{{0, 0}, {1, 0}, {0, 1}, {0, 1}, {2, 1}, {1, -1}, {1, 1}}
+
+		_, err := food.Generate(c)
+		snake.AssertError(t, err, snake.ErrBoardFull)
+		_, err = food.Generate(c[:6])
+		snake.AssertNoError(t, err)
+	})
+
+	t.Run("should generate food on resized board", func(t *testing.T) {
+		food := snake.NewFood(10, 10)
+		food.Resize(1, 2)
+
+		c, err := food.Generate([]snake.Coordinate{{0, 0}})
+		snake.AssertNoError(t, err)
+		snake.AssertCoordinate(t, c, snake.Coordinate{0, 1})
+	})
+	t.Run("should generate the same coordinates with the same seed", func(t *testing.T) {
+		first := snake.NewSeededFood(10, 10, 42)
+		second := snake.NewSeededFood(10, 10, 42)
+
+		for i := 0; i < 5; i++ {
+			want, err := first.Generate(nil)
+			snake.AssertNoError(t, err)
+			got, err := second.Generate(nil)
+			snake.AssertNoError(t, err)
+			snake.AssertCoordinate(t, got, want)
+		}
+	})
+	t.Run("should restore the random generator state", func(t *testing.T) {
+		food := snake.NewSeededFood(10, 10, 42)
+		food.Generate(nil)
+		food.Generate(nil)
+		restored := snake.NewFood(10, 10)
+
+		err := restored.RestoreRNG(food.RNGState())
+		snake.AssertNoError(t, err)
+
+		if restored.RNGState() != food.RNGState() {
+			t.Errorf("got state %+v, want %+v", restored.RNGState(), food.RNGState())
+		}
+		for i := 0; i < 5; i++ {
+			want, _ := food.Generate(nil)
+			got, _ := restored.Generate(nil)
+			snake.AssertCoordinate(t, got, want)
+		}
+	})
 }
@@ -1,6 +1,11 @@
 package snake
 
-import "time"
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
 
 // GameDirector interface defines how to coordinate the snake and food
 // interaction in a game.
@@ -17,7 +22,7 @@ type GameDirector interface {
 	// the new snake coordinates after each interval.
 	ReceiveSnakeCoordinates() <-chan []Coordinate
 	// ReceiveFoodCoordinate should expose a receiver channel which emits
-	// the new food coordinate after the snake eats the food.
+	// the new food coordinate after the food is eaten or moved.
 	ReceiveFoodCoordinate() <-chan Coordinate
 	// ReceiveGameResult should expose a receiver channel which emits
 	// when the game is won or is lost.
@@ -27,112 +32,933 @@ type GameDirector interface {
 	Restart(d time.Duration)
 	// Quit should stop the game internal go routine and then release resources.
 	Quit()
+	// Resize should set the board width and height of the games started
+	// by the next Restart.
+	Resize(width, height int)
+	// Pause should stop moving the snake until Resume or Restart are called.
+	Pause()
+	// Resume should resume moving the snake after Pause.
+	Resume()
+	// Save should return the full state of the game in progress.
+	Save() SaveState
+	// Restore should restore the game state s, which the game should
+	// continue from when started. It should be called before Start.
+	Restore(s SaveState) error
 }
 
-// Game coordinates the snake behaviour with the cloak ticks.
+// resizer is implemented by food generators which can change board size.
+type resizer interface {
+	Resize(width, height int)
+}
+
+// rngSaver is implemented by food generators whose random generator
+// state can be saved and restored.
+type rngSaver interface {
+	RNGState() RNGState
+	RestoreRNG(s RNGState) error
+}
+
+// portalGame is implemented by games played on a board with portals.
+type portalGame interface {
+	Portals() []Portal
+}
+
+// levelGame is implemented by games which can play the campaign levels.
+type levelGame interface {
+	SetLevel(l Level) error
+}
+
+// rulesGame is implemented by games which can be played with the rules
+// of the game modes.
+type rulesGame interface {
+	SetRules(r Rules)
+}
+
+// lifeGame is implemented by games which send their lives.
+type lifeGame interface {
+	ReceiveLives() <-chan Life
+}
+
+// lifeView is implemented by views which blink the invulnerable snake.
+type lifeView interface {
+	SetInvulnerable(ticks int)
+}
+
+// winGame is implemented by games which can be won by a win condition.
+type winGame interface {
+	SetWinCondition(w WinCondition)
+}
+
+// portalView is implemented by views which draw the portals.
+type portalView interface {
+	SetPortals(portals []Portal)
+}
+
+// entityGame is implemented by games with entities moving on the board.
+type entityGame interface {
+	ReceiveEntities() <-chan []Entity
+}
+
+// entityView is implemented by views which draw the entities.
+type entityView interface {
+	SetEntities(entities []Entity)
+}
+
+// frameView is implemented by views which display the snake and food
+// coordinates together with the game status in a single screen update.
+type frameView interface {
+	RefreshFrame(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate, s Status)
+}
+
+// statusView is implemented by views which display the game status.
+type statusView interface {
+	RefreshStatus(s Status)
+}
+
+// resizeView is implemented by views which display boards of any size and
+// send the board size which fits the display after it is resized.
+type resizeView interface {
+	SetBoardSize(width, height int)
+	ReceiveResize() <-chan Size
+}
+
+// menuView is implemented by views which display the menus and send
+// the menu item selection and the pause inputs from the user.
+type menuView interface {
+	DisplayMenu(m Menu)
+	ReceiveSelectSignal() <-chan struct{}
+	ReceivePauseSignal() <-chan struct{}
+}
+
+// saveView is implemented by views which send the save game input from the user.
+type saveView interface {
+	ReceiveSaveSignal() <-chan struct{}
+}
+
+// elapsedGame is implemented by games which tell the game time elapsed.
+type elapsedGame interface {
+	Elapsed() time.Duration
+}
+
+// faceGame is implemented by games which tell the snake face direction.
+type faceGame interface {
+	Face() Direction
+}
+
+// faceView is implemented by views which draw the snake head
+// towards its face direction.
+type faceView interface {
+	SetFace(d Direction)
+}
+
+// scoreGame is implemented by games which send their score
+// each time it changes.
+type scoreGame interface {
+	ReceiveScore() <-chan int
+}
+
+// arenaGame is implemented by games whose arena shrinks.
+type arenaGame interface {
+	ReceiveArena() <-chan Bounds
+}
+
+// arenaView is implemented by views which draw the arena walls.
+type arenaView interface {
+	SetArena(b Bounds)
+}
+
+// opponentGame is implemented by games with opponent snakes.
+type opponentGame interface {
+	ReceiveOpponents() <-chan []OpponentState
+}
+
+// opponentView is implemented by views which draw the opponent snakes.
+type opponentView interface {
+	SetOpponents(opponents []OpponentState)
+}
+
+// Game coordinates the snake behaviour with the clock ticks.
 type Game struct {
 	snake             *Snake
-	cloak             Cloak
+	clock             Clock
+	ticker            Ticker
 	foodProducer      FoodGenerator
 	snakeCoordinatesC chan []Coordinate
 	movesC            chan Direction
 	resultC           chan bool
 	foodCoordinate    Coordinate
 	foodC             chan Coordinate
-	quitEventRoutineC chan struct{}
+	scoreC            chan int
+	entities          []Entity
+	startEntities     []Entity
+	entitiesC         chan []Entity
+	livesC            chan Life
+	rivals            []*rival
+	opponents         []Opponent
+	opponentsC        chan []OpponentState
+	arenaC            chan Bounds
+	stopC             chan struct{}
+	stoppedC          chan struct{}
+	size              *Size
+	level             *Level
+	paused            bool
+	pausedMutex       sync.Mutex
+	interval          time.Duration
+	spectators        *hub
+	direction         Direction
+	score             int
+	eaten             int
+	ticks             int
+	restored          bool
+	win               WinCondition
+	rules             Rules
+	lives             int
+	invulnerable      int
+	stateMutex        sync.Mutex
+	doneC             chan struct{}
+	lifecycleMutex    sync.Mutex
 }
 
 // NewGame returns a pointer to Game, which handles snake
-// methods on cloak ticks
-func NewGame(snake *Snake, cloak Cloak, foodProducer FoodGenerator) *Game {
-	coordinatesChannel := make(chan []Coordinate)
-	movesChannel := make(chan Direction)
-	resultChannel := make(chan bool)
-	foodChannel := make(chan Coordinate)
-	quitEventRoutineChannel := make(chan struct{})
+// methods on the ticks of a clock ticker
+func NewGame(snake *Snake, clock Clock, foodProducer FoodGenerator) *Game {
 	return &Game{
-		snake,
-		cloak,
-		foodProducer,
-		coordinatesChannel,
-		movesChannel,
-		resultChannel,
-		Coordinate{},
-		foodChannel,
-		quitEventRoutineChannel,
+		snake:             snake,
+		clock:             clock,
+		foodProducer:      foodProducer,
+		snakeCoordinatesC: make(chan []Coordinate),
+		movesC:            make(chan Direction),
+		resultC:           make(chan bool),
+		foodC:             make(chan Coordinate),
+		scoreC:            make(chan int),
+		entitiesC:         make(chan []Entity),
+		livesC:            make(chan Life),
+		opponentsC:        make(chan []OpponentState),
+		arenaC:            make(chan Bounds),
+		spectators:        newHub(clock),
+		direction:         snake.Face(),
+		rules:             ClassicRules,
+		doneC:             make(chan struct{}),
 	}
 }
 
-// Start starts cloak to tick every d time.Duration,
+// Start starts a clock ticker to tick every d time.Duration,
 // then starts a go routine to loop on the ticker events
 // moving the snake and sending the new coordinates on
-// the internal channel
+// the internal channel. A game which is already started
+// or which quit is not started. A game which is not restored
+// is reset first if SetLevel or Resize were called before.
 func (g *Game) Start(d time.Duration) {
-	g.cloak.Start(d)
-	go g.eventRoutine()
+	g.lifecycleMutex.Lock()
+	defer g.lifecycleMutex.Unlock()
+	if g.stopC != nil || g.isDone() {
+		return
+	}
+	g.startTicker(d)
+	g.stateMutex.Lock()
+	g.interval = d
+	if !g.restored {
+		if g.applyLevel() {
+			g.reset()
+		}
+		g.direction = g.snake.Face()
+	}
+	g.stateMutex.Unlock()
+	g.startEventRoutine()
+}
+
+// Run starts the game like Start, then blocks until ctx is done or the game
+// quits. When ctx is done it quits the game and returns ctx.Err(),
+// otherwise it returns nil.
+func (g *Game) Run(ctx context.Context, d time.Duration) error {
+	g.Start(d)
+	select {
+	case <-ctx.Done():
+		g.Quit()
+		return ctx.Err()
+	case <-g.doneC:
+		return nil
+	}
+}
+
+// isDone reports whether the game quit.
+func (g *Game) isDone() bool {
+	select {
+	case <-g.doneC:
+		return true
+	default:
+		return false
+	}
+}
+
+// startTicker starts the ticker to tick every d time.Duration, discarding
+// the tick not received yet. It should be called with the lifecycle mutex
+// locked, while the event routine is stopped.
+func (g *Game) startTicker(d time.Duration) {
+	if g.ticker == nil {
+		g.ticker = g.clock.NewTicker(d)
+		return
+	}
+	g.ticker.Reset(d)
+	select {
+	case <-g.ticker.C():
+	default:
+	}
+}
+
+// startEventRoutine starts the event routine with new stop channels.
+// It should be called with the lifecycle mutex locked.
+func (g *Game) startEventRoutine() {
+	g.stopC, g.stoppedC = make(chan struct{}), make(chan struct{})
+	go g.eventRoutine(g.ticker.C(), g.stopC, g.stoppedC)
+}
+
+// stopEventRoutine stops the event routine, if started, and waits
+// for it to return. It should be called with the lifecycle mutex locked.
+func (g *Game) stopEventRoutine() {
+	if g.stopC == nil {
+		return
+	}
+	close(g.stopC)
+	<-g.stoppedC
+	g.stopC, g.stoppedC = nil, nil
 }
 
-func (g *Game) eventRoutine() {
-	direction := g.snake.Face()
-	g.sendInitSnakeAndFoodCoordinates()
+// eventRoutine moves the snake on the ticks received on tickC and changes
+// its direction on the moves received, until stopC is closed. After the
+// game result is sent the ticks are discarded. It closes stoppedC
+// when it returns.
+func (g *Game) eventRoutine(tickC <-chan time.Time, stopC <-chan struct{}, stoppedC chan<- struct{}) {
+	defer close(stoppedC)
+	if !g.sendInitSnakeAndFoodCoordinates(stopC) {
+		return
+	}
+	over := false
 	for {
 		select {
-		case <-g.cloak.Tick():
-			result := g.handleMove(direction)
+		case <-tickC:
+			if over || g.isPaused() {
+				continue
+			}
+			result, ok := g.handleMove(stopC)
+			if !ok {
+				return
+			}
 			if result != nil {
-				g.resultC <- *result
+				g.spectators.result(*result)
+				if !g.send(stopC, g.resultC, *result) {
+					return
+				}
+				over = true
 			}
 		case d := <-g.movesC:
-			if g.snake.IsValidMove(d) {
-				direction = d
-			}
-		case <-g.quitEventRoutineC:
+			g.turn(d)
+		case <-stopC:
 			return
 		}
 	}
 }
 
-func (g *Game) sendInitSnakeAndFoodCoordinates() {
-	g.snakeCoordinatesC <- g.snake.GetCoordinates()
-	var err error
-	g.foodCoordinate, err = g.foodProducer.Generate(g.snake.GetCoordinates())
-	if err != nil {
-		panic(err)
+// turn sets the direction the snake moves towards on the next tick,
+// if d is a valid move.
+func (g *Game) turn(d Direction) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	if g.snake.IsValidMove(d) {
+		g.direction = d
 	}
-	g.foodC <- g.foodCoordinate
 }
 
-func (g *Game) handleMove(d Direction) *bool {
-	result := false
-	err := g.snake.Move(d)
-	if err == ErrHeadOutOfBoard || err == ErrHeadHitBody {
-		return &result
+// send sends v on the channel ch, handling the moves received meanwhile.
+// It returns false if stopC was closed before v was received.
+func (g *Game) send(stopC <-chan struct{}, ch, v interface{}) bool {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: reflect.ValueOf(ch), Send: reflect.ValueOf(v)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(g.movesC)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(stopC)},
+	}
+	for {
+		switch i, d, _ := reflect.Select(cases); i {
+		case 0:
+			return true
+		case 1:
+			g.turn(d.Interface().(Direction))
+		default:
+			return false
+		}
 	}
-	coord := g.snake.GetCoordinates()
-	head := coord[0]
-	if head.X == g.foodCoordinate.X && head.Y == g.foodCoordinate.Y {
-		err = g.snake.Grow()
+}
+
+// sendInitSnakeAndFoodCoordinates sends the lives, if the rules have lives,
+// the entities, if any, the opponents, if any, the arena, if it shrinks,
+// the snake coordinates and a new food coordinate,
+// or the restored food coordinate if the game was restored.
+// It returns false if stopC was closed meanwhile.
+func (g *Game) sendInitSnakeAndFoodCoordinates(stopC <-chan struct{}) bool {
+	g.stateMutex.Lock()
+	coord, face := g.snake.GetCoordinates(), g.snake.Face()
+	if !g.restored {
+		var err error
+		g.foodCoordinate, err = g.foodProducer.Generate(g.occupied())
 		if err != nil {
-			return &result
+			panic(err)
+		}
+	}
+	g.restored = false
+	food, score, interval := g.foodCoordinate, g.score, g.interval
+	entities := copyEntities(g.entities)
+	opponents := rivalStates(g.rivals)
+	life, lives := Life{g.lives, g.invulnerable}, g.rules.Lives > 0
+	arena, shrinks := g.snake.Bounds(), g.rules.ShrinkEvery > 0
+	g.stateMutex.Unlock()
+
+	g.spectators.start(coord, face, interval, score)
+	if lives {
+		g.spectators.life(life)
+		if !g.send(stopC, g.livesC, life) {
+			return false
+		}
+	}
+	if entities != nil {
+		g.spectators.entities(entities)
+		if !g.send(stopC, g.entitiesC, entities) {
+			return false
+		}
+	}
+	if opponents != nil {
+		g.spectators.opponents(opponents)
+		if !g.send(stopC, g.opponentsC, opponents) {
+			return false
+		}
+	}
+	if shrinks {
+		g.spectators.arena(arena)
+		if !g.send(stopC, g.arenaC, arena) {
+			return false
+		}
+	}
+	if !g.send(stopC, g.snakeCoordinatesC, coord) {
+		return false
+	}
+	g.spectators.food(food)
+	return g.send(stopC, g.foodC, food)
+}
+
+// handleMove moves the snakes and the entities, respawning the snake if it
+// died with lives left, then sends the lives if the snake respawned, was
+// invulnerable or lost its last life, the entities, if any, the opponents, if any, the arena
+// if it shrank, the score if it changed, the new snake coordinates and
+// the new food coordinate if the food moved. It returns the game
+// result if the game is over, and false if stopC was closed meanwhile.
+func (g *Game) handleMove(stopC <-chan struct{}) (*bool, bool) {
+	g.stateMutex.Lock()
+	invulnerable := g.invulnerable > 0
+	if invulnerable {
+		g.invulnerable--
+	}
+	arena, score := g.snake.Bounds(), g.score
+	moved, foodMoved, result := g.move()
+	respawned := result != nil && !*result && g.lives > 1 && g.respawn()
+	if respawned {
+		moved, result = true, nil
+	}
+	livesOver := result != nil && !*result && g.rules.Lives > 0
+	if livesOver {
+		g.lives, g.invulnerable = 0, 0
+	}
+	coord, face, food := g.snake.GetCoordinates(), g.snake.Face(), g.foodCoordinate
+	entities := copyEntities(g.entities)
+	opponents := rivalStates(g.rivals)
+	life := Life{g.lives, g.invulnerable}
+	shrank := g.snake.Bounds() != arena
+	arena = g.snake.Bounds()
+	scored := g.score != score
+	score = g.score
+	g.stateMutex.Unlock()
+
+	if respawned || invulnerable || livesOver {
+		g.spectators.life(life)
+		if !g.send(stopC, g.livesC, life) {
+			return nil, false
+		}
+	}
+	if entities != nil {
+		g.spectators.entities(entities)
+		if !g.send(stopC, g.entitiesC, entities) {
+			return nil, false
+		}
+	}
+	if opponents != nil {
+		g.spectators.opponents(opponents)
+		if !g.send(stopC, g.opponentsC, opponents) {
+			return nil, false
 		}
-		coord = g.snake.GetCoordinates()
-		g.snakeCoordinatesC <- coord
-		g.foodCoordinate, err = g.foodProducer.Generate(coord)
+	}
+	if shrank {
+		g.spectators.arena(arena)
+		if !g.send(stopC, g.arenaC, arena) {
+			return nil, false
+		}
+	}
+	if scored {
+		g.spectators.score(score)
+		if !g.send(stopC, g.scoreC, score) {
+			return nil, false
+		}
+	}
+	if moved {
+		g.spectators.snake(coord, face)
+		if !g.send(stopC, g.snakeCoordinatesC, coord) {
+			return nil, false
+		}
+	}
+	if foodMoved {
+		g.spectators.food(food)
+		if !g.send(stopC, g.foodC, food) {
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// move moves the snake towards the last valid direction received, then
+// the opponents, then shrinks the arena if the rules say so, then moves
+// the entities and applies the rules. When the snake head hits the food
+// the snake grows and a new food is generated, when it hits a mouse the
+// snake grows and the mouse respawns. The game is lost when the snake
+// head hits a block, a hazard or an opponent, or when the blocks and the
+// hazards move on the snake head, unless the snake is invulnerable, and
+// when the snake is caught outside the shrunk arena. The game is won
+// when the snake fills the board, when the win condition is met, when
+// the time limit is reached or, with the last alive rules, when all the
+// opponents died. In the zen modes the snake is not moved if its head
+// would hit a board side, a block, a hazard or an opponent.
+// It returns whether the snake moved, whether the food moved, because
+// a snake ate it or the arena shrank over it, and the game result if the
+// game is over.
+// It should be called with the state mutex locked.
+func (g *Game) move() (moved, foodMoved bool, result *bool) {
+	lose, win, ate := false, true, false
+	g.ticks++
+	for i := range g.entities {
+		g.entities[i].Eaten = false
+	}
+	g.chooseRivals()
+	if !g.rules.Zen || !g.blocked() {
+		if ate, result = g.moveSnake(); result != nil {
+			return false, false, result
+		}
+		moved = true
+	}
+	if g.moveRivals(!ate) {
+		ate = true
+	}
+	if ate {
+		var err error
+		g.foodCoordinate, err = g.foodProducer.Generate(g.occupied())
 		if err != nil {
-			result = true
-			return &result
+			// the snakes filled the board
+			return moved, false, &win
 		}
-		g.foodC <- g.foodCoordinate
+		foodMoved = true
+	}
+	if g.collide() && !g.rules.Zen && g.invulnerable == 0 {
+		return moved, foodMoved, &lose
+	}
+	if g.rules.shrinksOn(g.ticks) {
+		caught, regenerated := g.shrink()
+		if caught {
+			return moved, foodMoved, &lose
+		}
+		foodMoved = foodMoved || regenerated
+	}
+	b := board{g.snake.Bounds(), g.snake.GetCoordinates(),
+		append(append(portalCells(g.snake.Portals()), g.foodCoordinate), rivalCells(g.rivals)...)}
+	if moveEntities(g.entities, g.ticks, b) && !g.rules.Zen && g.invulnerable == 0 {
+		return moved, foodMoved, &lose
+	}
+	if g.rules.placesObstacleOn(g.ticks) {
+		g.placeObstacle()
+	}
+	p := g.progress()
+	if g.win != nil && g.win.Met(p) || g.rules.timeUp(p.Elapsed) || g.rules.LastAlive && lastAlive(g.rivals) {
+		return moved, foodMoved, &win
+	}
+	return moved, foodMoved, nil
+}
+
+// shrink shrinks the arena by its outer ring, unless it is already as small
+// as it gets. The opponents caught outside the shrunk arena die, the entities
+// outside are removed and the food outside is generated again inside.
+// It returns whether the snake was caught outside, and whether the food
+// was moved inside.
+// It should be called with the state mutex locked.
+func (g *Game) shrink() (caught, foodMoved bool) {
+	b := g.snake.Bounds().shrunk()
+	if b == g.snake.Bounds() {
+		return false, false
+	}
+	caught = !b.containsAll(g.snake.GetCoordinates())
+	g.snake.bounds = b
+	for _, r := range g.rivals {
+		r.snake.bounds = b
+		if r.alive && !b.containsAll(r.snake.GetCoordinates()) {
+			r.alive = false
+		}
+	}
+	entities := g.entities[:0]
+	for _, e := range g.entities {
+		if b.Contains(e.Position) {
+			entities = append(entities, e)
+		}
+	}
+	g.entities = entities
+	if caught || b.Contains(g.foodCoordinate) {
+		return caught, false
+	}
+	food, err := g.foodProducer.Generate(g.occupied())
+	if err != nil {
+		return false, false
+	}
+	g.foodCoordinate = food
+	return false, true
+}
+
+// moveSnake moves the snake towards the last valid direction received,
+// eating the food or the mouse hit by its head. When the rules cut the tail,
+// the snake hitting its body bites off its tail. It returns whether the
+// snake ate the food, and the game result if the snake head hits a board
+// side, its body, or a block or a hazard while it is not invulnerable.
+// It should be called with the state mutex locked.
+func (g *Game) moveSnake() (ate bool, result *bool) {
+	lose := false
+	err := g.snake.Move(g.direction)
+	if err == ErrHeadHitBody && g.rules.cutsTail() {
+		g.cutTail()
+		err = g.snake.Move(g.direction)
+	}
+	if err == ErrHeadOutOfBoard || err == ErrHeadHitBody {
+		return false, &lose
+	}
+	head := g.snake.GetCoordinates()[0]
+	if i := entityAt(g.entities, head); i >= 0 {
+		switch {
+		case !g.entities[i].harmful():
+			if err = g.eatMouse(i); err != nil {
+				return false, &lose
+			}
+		case g.invulnerable == 0:
+			return false, &lose
+		}
+	}
+	if head == g.foodCoordinate {
+		if err = g.snake.Grow(); err != nil {
+			return false, &lose
+		}
+		g.score++
+		g.eaten++
+		ate = true
+	}
+	return ate, nil
+}
+
+// chooseRivals lets the alive opponents choose the direction they move
+// towards on the tick, seeing the board as it is before the snakes move.
+// It should be called with the state mutex locked.
+func (g *Game) chooseRivals() {
+	for _, r := range g.rivals {
+		r.ate = false
+		if r.alive {
+			r.choose(g.arenaFor(r.snake))
+		}
+	}
+}
+
+// arenaFor returns the board as seen by the strategy driving s, which is
+// the snake or the snake of an alive opponent.
+// It should be called with the state mutex locked.
+func (g *Game) arenaFor(s *Snake) Arena {
+	obstacles := portalCells(g.snake.Portals())
+	for _, e := range g.entities {
+		if e.harmful() {
+			obstacles = append(obstacles, e.Position)
+		}
+	}
+	var others [][]Coordinate
+	if s != g.snake {
+		others = append(others, g.snake.GetCoordinates())
+	}
+	for _, o := range g.rivals {
+		if o.snake != s && o.alive {
+			others = append(others, o.snake.GetCoordinates())
+		}
+	}
+	return Arena{
+		Width:     g.snake.width,
+		Height:    g.snake.height,
+		Snake:     s.GetCoordinates(),
+		Face:      s.Face(),
+		Rivals:    others,
+		Food:      g.foodCoordinate,
+		Obstacles: obstacles,
+		Bounds:    g.snake.Bounds(),
+	}
+}
+
+// startHeadless resets a headless game, which is never started and is
+// played calling step, then generates its first food. It returns
+// ErrBoardFull if the snakes fill the board.
+func (g *Game) startHeadless() error {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.reset()
+	food, err := g.foodProducer.Generate(g.occupied())
+	if err != nil {
+		return err
+	}
+	g.foodCoordinate = food
+	return nil
+}
+
+// step plays a tick of a headless game: the snake moves towards d, or
+// towards its face direction if d is not a valid move, then the opponents
+// move and the rules apply like on the clock ticks, without sending on the
+// receive channels. It returns whether the snake ate, and the game result
+// if the game is over.
+func (g *Game) step(d Direction) (ate bool, result *bool) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.direction = g.snake.Face()
+	if g.snake.IsValidMove(d) {
+		g.direction = d
+	}
+	eaten := g.eaten
+	_, _, result = g.move()
+	return g.eaten > eaten, result
+}
+
+// stepBot plays a tick of a headless game like step, in which the bot
+// player chooses the direction of the snake like the opponents do.
+func (g *Game) stepBot(player *rival) *bool {
+	g.stateMutex.Lock()
+	player.choose(g.arenaFor(g.snake))
+	g.stateMutex.Unlock()
+	_, result := g.step(player.direction)
+	return result
+}
+
+// engineState returns the snapshot of a headless game played by an Engine,
+// copying the snake coordinates only.
+func (g *Game) engineState() EngineState {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	return EngineState{
+		Width:  g.snake.width,
+		Height: g.snake.height,
+		Snake:  append([]Coordinate{}, g.snake.GetCoordinates()...),
+		Food:   g.foodCoordinate,
+		Face:   g.snake.Face(),
+		Score:  g.score,
+		Steps:  g.ticks,
+	}
+}
+
+// moveRivals moves the alive opponents in their order towards the direction
+// they chose. An opponent dies when its head hits a board side, a wall, its body,
+// a block or a hazard. If food is true the first opponent whose head hits
+// the food eats it and grows. It returns whether an opponent ate the food.
+// It should be called with the state mutex locked.
+func (g *Game) moveRivals(food bool) (ate bool) {
+	for _, r := range g.rivals {
+		if !r.alive {
+			continue
+		}
+		if err := r.snake.Move(r.direction); err != nil {
+			r.alive = false
+			continue
+		}
+		head := r.snake.GetCoordinates()[0]
+		if i := entityAt(g.entities, head); i >= 0 && g.entities[i].harmful() {
+			r.alive = false
+			continue
+		}
+		if food && !ate && head == g.foodCoordinate && r.snake.Grow() == nil {
+			r.score++
+			r.ate, ate = true, true
+		}
+	}
+	return ate
+}
+
+// collide kills the opponents whose head is on another snake after all the
+// snakes moved, so that the collisions do not depend on the order in which
+// the snakes move: two heads on the same cell kill both snakes. It returns
+// whether the snake head is on an opponent.
+// It should be called with the state mutex locked.
+func (g *Game) collide() bool {
+	player := g.snake.GetCoordinates()
+	hit := false
+	var dead []*rival
+	for i, r := range g.rivals {
+		if !r.alive {
+			continue
+		}
+		c := r.snake.GetCoordinates()
+		if contains(c, player[0]) {
+			hit = true
+		}
+		others := player
+		for j, o := range g.rivals {
+			if j != i && o.alive {
+				others = append(append([]Coordinate{}, others...), o.snake.GetCoordinates()...)
+			}
+		}
+		if contains(others, c[0]) {
+			dead = append(dead, r)
+		}
+	}
+	for _, r := range dead {
+		r.alive = false
+	}
+	return hit
+}
+
+// placeRivals returns the rivals playing the opponents, placed with their
+// initial length on the rows above and below the snake, off the snake and
+// the cells ahead of its head, the portals, the entities and each other.
+// It returns false if an opponent does not fit the board, and is not alive.
+// It should be called with the state mutex locked.
+func (g *Game) placeRivals(opponents []Opponent) ([]*rival, bool) {
+	if opponents == nil {
+		return nil, true
+	}
+	occupied := append(append([]Coordinate{}, g.snake.occupied()...), entityCells(g.entities)...)
+	ahead := g.snake.GetCoordinates()[0]
+	for i := 0; i < respawnClearance; i++ {
+		ahead = step(ahead, g.snake.Face())
+		occupied = append(occupied, ahead)
+	}
+	rivals, fit := make([]*rival, len(opponents)), true
+	for i, o := range opponents {
+		r := newRival(o, g.snake)
+		r.alive = r.snake.spawn(opponentStart(g.snake, i), occupied)
+		r.direction = r.snake.Face()
+		if r.alive {
+			occupied = append(occupied, r.snake.GetCoordinates()...)
+		}
+		fit = fit && r.alive
+		rivals[i] = r
+	}
+	return rivals, fit
+}
+
+// respawn takes a life and places the snake back with its initial length,
+// off the portals, the entities, the opponents and the food, with free cells ahead of its
+// head. The respawned snake is invulnerable for RespawnInvulnerability ticks.
+// It returns false if the snake does not fit the board.
+// It should be called with the state mutex locked.
+func (g *Game) respawn() bool {
+	occupied := append(append(append(append([]Coordinate{}, portalCells(g.snake.Portals())...), entityCells(g.entities)...), rivalCells(g.rivals)...), g.foodCoordinate)
+	if !g.snake.respawn(occupied) {
+		return false
+	}
+	g.lives--
+	g.invulnerable = RespawnInvulnerability
+	g.direction = g.snake.Face()
+	return true
+}
+
+// cutTail bites off the snake tail from the body segment which the head
+// would hit onward, taking a point off the score for each segment bitten off.
+// It should be called with the state mutex locked.
+func (g *Game) cutTail() {
+	g.score -= g.snake.biteTail(g.direction)
+	if g.score < 0 {
+		g.score = 0
+	}
+}
+
+// blocked reports whether the snake head moving towards the last valid
+// direction received would hit a board side, a wall, a block, a hazard or an opponent.
+// It should be called with the state mutex locked.
+func (g *Game) blocked() bool {
+	next := g.snake.setHead(g.direction)
+	if !g.snake.Bounds().Contains(next) {
+		return true
+	}
+	if contains(rivalCells(g.rivals), next) {
+		return true
+	}
+	i := entityAt(g.entities, next)
+	return i >= 0 && g.entities[i].harmful()
+}
+
+// placeObstacle places a still block on a free cell which is not on the food
+// nor on the next cells ahead of the snake head, so that the snake has time
+// to avoid it. The block is not placed if there are no free cells.
+// It should be called with the state mutex locked.
+func (g *Game) placeObstacle() {
+	occupied := append(append([]Coordinate{}, g.occupied()...), g.foodCoordinate)
+	ahead := g.snake.GetCoordinates()[0]
+	board := fullBounds(g.snake.width, g.snake.height)
+	for i := 0; i < obstacleDistance; i++ {
+		ahead = step(ahead, g.direction)
+		if board.Contains(ahead) && !contains(occupied, ahead) {
+			occupied = append(occupied, ahead)
+		}
+	}
+	position, err := g.foodProducer.Generate(occupied)
+	if err != nil {
+		return
+	}
+	g.entities = append(g.entities, Entity{Kind: Block, Position: position, Path: []Coordinate{position}})
+}
+
+// eatMouse grows the snake, increments the score and respawns the i-th
+// entity on a free cell, removing it if the board is full.
+// It should be called with the state mutex locked.
+func (g *Game) eatMouse(i int) error {
+	if err := g.snake.Grow(); err != nil {
+		return err
+	}
+	g.score++
+	g.eaten++
+	others := append(append([]Entity{}, g.entities[:i]...), g.entities[i+1:]...)
+	occupied := append(append([]Coordinate{}, g.occupied()...), g.foodCoordinate)
+	position, err := g.foodProducer.Generate(occupied)
+	if err != nil {
+		g.entities = others
 		return nil
 	}
-	g.snakeCoordinatesC <- coord
+	g.entities[i].Position, g.entities[i].Eaten = position, true
 	return nil
 }
 
+// occupied returns the snake coordinates, the portal cells, the entities
+// positions, the opponents coordinates and the walls, on which the food can not be generated.
+// It should be called with the state mutex locked.
+func (g *Game) occupied() []Coordinate {
+	walls := g.walls()
+	if len(g.entities) == 0 && len(g.rivals) == 0 && len(walls) == 0 {
+		return g.snake.occupied()
+	}
+	return append(append(append(append([]Coordinate{}, g.snake.occupied()...), entityCells(g.entities)...), rivalCells(g.rivals)...), walls...)
+}
+
+// walls returns the board cells outside the arena.
+// It should be called with the state mutex locked.
+func (g *Game) walls() []Coordinate {
+	return g.snake.Bounds().outside(g.snake.width, g.snake.height)
+}
+
 // SendMove sends d Direction to the internal Direction channel
-// which will be pooled inside the Start go routine to change snake direction
+// which will be pooled inside the Start go routine to change snake direction.
+// The direction is discarded if the game quit.
 func (g *Game) SendMove(d Direction) {
-	g.movesC <- d
+	select {
+	case g.movesC <- d:
+	case <-g.doneC:
+	}
 }
 
 // ReceiveSnakeCoordinates returns the snake coordinates receive channel.
@@ -140,30 +966,401 @@ func (g *Game) ReceiveSnakeCoordinates() <-chan []Coordinate {
 	return g.snakeCoordinatesC
 }
 
+// Face returns the direction the snake faces, which is the direction
+// of its last move.
+func (g *Game) Face() Direction {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	return g.snake.Face()
+}
+
 // ReceiveFoodCoordinate returns the food coordinate receive channel.
 func (g *Game) ReceiveFoodCoordinate() <-chan Coordinate {
 	return g.foodC
 }
 
+// ReceiveScore returns the score receive channel, on which the game sends
+// the score before the snake coordinates each time it changes, when the
+// snake eats the food or a mouse and when it bites off its tail.
+func (g *Game) ReceiveScore() <-chan int {
+	return g.scoreC
+}
+
+// ReceiveLives returns the lives receive channel, on which the game sends
+// the lives before the entities when the game starts, when the snake
+// respawns and on each tick while the snake is invulnerable, if the
+// rules have lives.
+func (g *Game) ReceiveLives() <-chan Life {
+	return g.livesC
+}
+
+// ReceiveOpponents returns the opponents receive channel, on which the game
+// sends the opponents after the entities on each tick, if the game has
+// opponents. The opponents which ate the food on the tick are marked as Ate,
+// so the food coordinate which follows was not eaten by the snake.
+func (g *Game) ReceiveOpponents() <-chan []OpponentState {
+	return g.opponentsC
+}
+
+// SetOpponents sets the opponent snakes sharing the board with the snake in
+// the games started from now on, placing them on the board. Each tick the
+// opponents choose their moves seeing the board before the snakes move, then
+// the snake moves, then the opponents move in their order, and the snake
+// eats the food first if more than one snake reaches it. The collisions
+// between the snakes are resolved after all the snakes moved. It returns
+// ErrUnknownStrategy if the strategy of an opponent is not one of Strategies,
+// and ErrInvalidOpponents if an opponent difficulty is unknown or the
+// opponents do not fit the board. It should be called before Start,
+// after SetEntities.
+func (g *Game) SetOpponents(opponents []Opponent) error {
+	for _, o := range opponents {
+		if err := o.validate(); err != nil {
+			return err
+		}
+	}
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	rivals, ok := g.placeRivals(opponents)
+	if !ok {
+		return ErrInvalidOpponents
+	}
+	g.opponents = append([]Opponent(nil), opponents...)
+	g.rivals = rivals
+	return nil
+}
+
+// ReceiveArena returns the arena receive channel, on which the game sends
+// the arena bounds after the opponents when the game starts and when the
+// arena shrinks, if the rules shrink the arena.
+func (g *Game) ReceiveArena() <-chan Bounds {
+	return g.arenaC
+}
+
+// ReceiveEntities returns the entities receive channel, on which the game
+// sends the entities before the snake coordinates on each tick, if the
+// game has entities. The mice eaten on the tick are marked as Eaten.
+func (g *Game) ReceiveEntities() <-chan []Entity {
+	return g.entitiesC
+}
+
+// SetEntities sets the entities moving on the board of the games started
+// from now on. It returns ErrInvalidEntities if an entity is outside the
+// board, shares its cell with another entity, with the snake or with
+// a portal, or is not a valid entity. It should be called before Start.
+func (g *Game) SetEntities(entities []Entity) error {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	if err := validateEntities(entities, g.snake.width, g.snake.height, append(append([]Coordinate{}, g.snake.occupied()...), rivalCells(g.rivals)...)); err != nil {
+		return err
+	}
+	g.startEntities = copyEntities(entities)
+	g.entities = copyEntities(entities)
+	return nil
+}
+
+// Elapsed returns the game time elapsed, which is the number of ticks
+// by the interval, so the pauses do not count.
+func (g *Game) Elapsed() time.Duration {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	return time.Duration(g.ticks) * g.interval
+}
+
+// progress returns the game progress on which the win condition is evaluated.
+// It should be called with the state mutex locked.
+func (g *Game) progress() Progress {
+	return Progress{g.score, g.eaten, len(g.snake.GetCoordinates()), time.Duration(g.ticks) * g.interval}
+}
+
+// SetWinCondition sets the condition which wins the games when it is met,
+// besides filling the board. A nil condition wins only filling the board.
+func (g *Game) SetWinCondition(w WinCondition) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.win = w
+}
+
+// SetRules sets the rules of the game mode, and the lives of the next
+// game. It should be called before Start or Restart.
+func (g *Game) SetRules(r Rules) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.rules = r
+	g.lives = r.Lives
+}
+
+// SetLevel sets the board size, the portals, the entities and the goal of the
+// game started by the next Start or Restart, replacing the ones set before,
+// while the game in progress is left as it is. The board size is kept if the
+// level has none. It returns ErrInvalidCampaign if the level is not valid.
+func (g *Game) SetLevel(l Level) error {
+	if err := l.validate(); err != nil {
+		return err
+	}
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	l.Portals = append([]Portal{}, l.Portals...)
+	l.Entities = copyEntities(l.Entities)
+	g.level = &l
+	return nil
+}
+
+// applyLevel applies the level set by SetLevel and the board size set by
+// Resize or by the level, if any, returning whether the board changed.
+// It should be called with the state mutex locked, before the reset.
+func (g *Game) applyLevel() bool {
+	if g.level == nil && g.size == nil {
+		return false
+	}
+	if l := g.level; l != nil {
+		if l.Width > 0 && l.Height > 0 {
+			g.size = &Size{l.Width, l.Height}
+		}
+		g.snake.portals = l.Portals
+		g.startEntities = l.Entities
+		g.win = l.Goal
+		g.level = nil
+	}
+	if g.size != nil {
+		g.snake.Resize(g.size.Width, g.size.Height)
+		if r, ok := g.foodProducer.(resizer); ok {
+			r.Resize(g.size.Width, g.size.Height)
+		}
+		g.size = nil
+	}
+	return true
+}
+
 // ReceiveGameResult returns the game result receive channel.
 func (g *Game) ReceiveGameResult() <-chan bool {
 	return g.resultC
 }
 
-// Restart stops the game internal go routine, reset the snake and starts
-// a new game event loop internal go routine.
+// Restart stops the game internal go routine, restarts the ticker to tick
+// every d time.Duration, reset the snake and starts a new game event loop
+// internal go routine. If SetLevel or Resize were called before, the level is
+// applied and the snake and the food producer are resized before the reset.
+// A paused game is resumed.
+// A game which is not started yet is started, a game which quit is not
+// restarted.
 func (g *Game) Restart(d time.Duration) {
-	g.quitEventRoutineC <- struct{}{}
+	g.lifecycleMutex.Lock()
+	defer g.lifecycleMutex.Unlock()
+	if g.isDone() {
+		return
+	}
+	g.stopEventRoutine()
+	g.Resume()
+	g.startTicker(d)
+	g.stateMutex.Lock()
+	g.interval = d
+	g.applyLevel()
+	g.reset()
+	g.stateMutex.Unlock()
+	g.startEventRoutine()
+}
+
+// reset places the snakes and the entities back on their start cells
+// and clears the score, the ticks and the lives taken.
+// It should be called with the state mutex locked.
+func (g *Game) reset() {
 	g.snake.Reset()
-	go g.eventRoutine()
+	g.resetEntities()
+	g.rivals, _ = g.placeRivals(g.opponents)
+	g.direction = g.snake.Face()
+	g.score, g.eaten, g.ticks, g.restored = 0, 0, 0, false
+	g.lives, g.invulnerable = g.rules.Lives, 0
 }
 
-// Quit stops the game internal go routine, then closes all the internal channels.
+// resetEntities places the entities back on their start cells, removing
+// the ones which do not fit the board, are on the snake or on a portal.
+// It should be called with the state mutex locked, after the snake reset.
+func (g *Game) resetEntities() {
+	g.entities = nil
+	for _, e := range copyEntities(g.startEntities) {
+		if validateEntities([]Entity{e}, g.snake.width, g.snake.height, g.snake.occupied()) == nil {
+			g.entities = append(g.entities, e)
+		}
+	}
+}
+
+// Portals returns the portals of the board, which Restart removes
+// if they do not fit the resized board.
+func (g *Game) Portals() []Portal {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	return append([]Portal{}, g.snake.Portals()...)
+}
+
+// Resize sets the board width and height which will be applied to the snake
+// and to the food producer, if it implements a Resize(width, height int) method,
+// on the next Start or Restart.
+func (g *Game) Resize(width, height int) {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.size = &Size{width, height}
+}
+
+// Pause stops moving the snake on the ticks until Resume or Restart are called.
+func (g *Game) Pause() {
+	g.pausedMutex.Lock()
+	defer g.pausedMutex.Unlock()
+	g.paused = true
+}
+
+// Resume resumes moving the snake on the ticks.
+func (g *Game) Resume() {
+	g.pausedMutex.Lock()
+	defer g.pausedMutex.Unlock()
+	g.paused = false
+}
+
+func (g *Game) isPaused() bool {
+	g.pausedMutex.Lock()
+	defer g.pausedMutex.Unlock()
+	return g.paused
+}
+
+// Save returns the full state of the game in progress, including the food
+// generator random state if the food producer implements the RNGState and
+// RestoreRNG(s RNGState) error methods.
+// The win condition is saved as the Goal expressing it, or as the zero Goal
+// if no goal expresses it.
+func (g *Game) Save() SaveState {
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	s := SaveState{
+		Width:        g.snake.width,
+		Height:       g.snake.height,
+		Snake:        append([]Coordinate{}, g.snake.GetCoordinates()...),
+		Portals:      append([]Portal{}, g.snake.Portals()...),
+		Entities:     copyEntities(g.entities),
+		Opponents:    rivalStates(g.rivals),
+		Lives:        g.lives,
+		Invulnerable: g.invulnerable,
+		Face:         g.snake.Face(),
+		Direction:    g.direction,
+		Food:         g.foodCoordinate,
+		Score:        g.score,
+		Eaten:        g.eaten,
+		Tick:         g.ticks,
+		Interval:     g.interval,
+		Rules:        g.rules,
+	}
+	if goal, ok := goalOf(g.win); ok {
+		s.Goal = goal
+	}
+	if t := g.snake.LastTail(); t != nil {
+		lastTail := *t
+		s.LastTail = &lastTail
+	}
+	if b := g.snake.Bounds(); b != fullBounds(g.snake.width, g.snake.height) {
+		s.Arena = &b
+	}
+	if r, ok := g.foodProducer.(rngSaver); ok {
+		rng := r.RNGState()
+		s.RNG = &rng
+	}
+	return s
+}
+
+// Restore restores the game state s, resizing the snake and the food producer
+// to the saved board. Start continues the restored game, sending the restored
+// food coordinate instead of generating a new one. It returns ErrInvalidSave
+// if s is not valid. It should be called before Start.
+func (g *Game) Restore(s SaveState) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	g.stateMutex.Lock()
+	defer g.stateMutex.Unlock()
+	g.snake.Resize(s.Width, s.Height)
+	g.snake.portals = append([]Portal{}, s.Portals...)
+	if err := g.snake.Restore(s.Snake, s.Face, s.LastTail); err != nil {
+		return err
+	}
+	if s.Arena != nil {
+		if err := g.snake.SetBounds(*s.Arena); err != nil {
+			return err
+		}
+	}
+	if r, ok := g.foodProducer.(resizer); ok {
+		r.Resize(s.Width, s.Height)
+	}
+	if r, ok := g.foodProducer.(rngSaver); ok && s.RNG != nil {
+		if err := r.RestoreRNG(*s.RNG); err != nil {
+			return err
+		}
+	}
+	g.foodCoordinate = s.Food
+	g.entities = copyEntities(s.Entities)
+	g.restoreRivals(s.Opponents)
+	g.win = s.Goal
+	g.rules = s.Rules
+	g.lives, g.invulnerable = s.Lives, s.Invulnerable
+	if g.startEntities == nil {
+		g.startEntities = copyEntities(s.Entities)
+	}
+	g.direction = s.Direction
+	g.score, g.eaten, g.ticks, g.interval = s.Score, s.Eaten, s.Tick, s.Interval
+	g.restored = true
+	return nil
+}
+
+// restoreRivals restores the opponents states, setting the opponents
+// of the next games if they were not set.
+// It should be called with the state mutex locked, after the snake restore.
+func (g *Game) restoreRivals(states []OpponentState) {
+	g.rivals = nil
+	if states == nil {
+		return
+	}
+	g.rivals = make([]*rival, len(states))
+	opponents := make([]Opponent, len(states))
+	for i, o := range states {
+		r := newRival(o.Opponent, g.snake)
+		if o.Alive && r.snake.Restore(o.Snake, o.Face, nil) == nil {
+			r.alive, r.direction = true, o.Face
+		}
+		r.score = o.Score
+		g.rivals[i], opponents[i] = r, o.Opponent
+	}
+	if g.opponents == nil {
+		g.opponents = opponents
+	}
+}
+
+// Watch renders the game on view until the returned Spectator is stopped,
+// the view sends the quit signal or the game quits. The view receives
+// the current game state first, then each change. Its other inputs
+// are discarded, so view can not play the game.
+func (g *Game) Watch(view ViewHandler) *Spectator {
+	return g.spectators.watch(view)
+}
+
+// Quit stops the game internal go routine, waiting for it to return even if
+// it is blocked sending on a receive channel, stops the ticker and the spectators, then
+// closes the snake coordinates, food coordinate, entities, lives, opponents, arena and game result channels.
+// Start, Restart and SendMove do nothing after Quit.
+// It can be called more than once and from any go routine.
 func (g *Game) Quit() {
-	g.quitEventRoutineC <- struct{}{}
-	defer close(g.snakeCoordinatesC)
-	defer close(g.movesC)
-	defer close(g.resultC)
-	defer close(g.foodC)
-	defer close(g.quitEventRoutineC)
+	g.lifecycleMutex.Lock()
+	defer g.lifecycleMutex.Unlock()
+	if g.isDone() {
+		return
+	}
+	close(g.doneC)
+	g.stopEventRoutine()
+	if g.ticker != nil {
+		g.ticker.Stop()
+	}
+	g.spectators.close()
+	close(g.snakeCoordinatesC)
+	close(g.resultC)
+	close(g.foodC)
+	close(g.entitiesC)
+	close(g.livesC)
+	close(g.opponentsC)
+	close(g.arenaC)
+	close(g.scoreC)
 }
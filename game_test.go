@@ -1,6 +1,10 @@
 package snake_test
 
 import (
+	"context"
+	"reflect"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,18 +28,17 @@ func TestGame(t *testing.T) {
 	t.Run("should start game", func(t *testing.T) {
 		s := snake.NewSnake(width, height)
 		snakeInitCoordinates := s.GetCoordinates()
-		cloak := NewStubCloak()
-		defer cloak.Stop()
-		g := snake.NewGame(s, cloak, fs)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
 		snake.AssertCoordinates(t, sc, snakeInitCoordinates)
 		_, _, fc := snake.WaitAndReceiveGameChannels(t, g)
 		snake.AssertCoordinate(t, *fc, foodSeededCoordinates[0].Coord)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 		snake.WaitAndReceiveGameChannels(t, g)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 		got, r, _ := snake.WaitAndReceiveGameChannels(t, g)
 		want := []snake.Coordinate{
 			{34, 30},
@@ -48,9 +51,8 @@ func TestGame(t *testing.T) {
 
 	t.Run("snake should change direction", func(t *testing.T) {
 		s := snake.NewSnake(width, height)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
-		g := snake.NewGame(s, cloak, fs)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -58,7 +60,7 @@ func TestGame(t *testing.T) {
 		// skip init food coordinate send
 		snake.WaitAndReceiveGameChannels(t, g)
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		got, r, _ := snake.WaitAndReceiveGameChannels(t, g)
 		want := []snake.Coordinate{
@@ -70,7 +72,7 @@ func TestGame(t *testing.T) {
 		snake.AssertCoordinates(t, got, want)
 
 		g.SendMove(snake.Up)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		got, r, _ = snake.WaitAndReceiveGameChannels(t, g)
 		want = []snake.Coordinate{
@@ -84,9 +86,8 @@ func TestGame(t *testing.T) {
 
 	t.Run("snake should keep moving on face direction when an invalid move is sent", func(t *testing.T) {
 		s := snake.NewSnake(width, height)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
-		g := snake.NewGame(s, cloak, fs)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -95,7 +96,7 @@ func TestGame(t *testing.T) {
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		g.SendMove(snake.Right)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		got, r, _ := snake.WaitAndReceiveGameChannels(t, g)
 		want := []snake.Coordinate{
@@ -109,9 +110,8 @@ func TestGame(t *testing.T) {
 
 	t.Run("game should end with a lose when snake moves out of board", func(t *testing.T) {
 		s := snake.NewSnake(10, 10)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
-		g := snake.NewGame(s, cloak, fs)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -120,11 +120,11 @@ func TestGame(t *testing.T) {
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		for i := 0; i < 6; i++ {
-			cloak.AddTick()
+			clock.Advance(time.Microsecond)
 			snake.WaitAndReceiveGameChannels(t, g)
 		}
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		_, result, _ := snake.WaitAndReceiveGameChannels(t, g)
 		if result == nil {
@@ -138,15 +138,14 @@ func TestGame(t *testing.T) {
 
 	t.Run("snake should grow after eating food", func(t *testing.T) {
 		s := snake.NewSnake(10, 10)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 		sf := &snake.FoodStub{}
 		sf.Seed([]snake.FoodStubValue{
 			{snake.Coordinate{5, 5}, nil},
 			{snake.Coordinate{4, 5}, nil},
 			{snake.Coordinate{3, 5}, nil},
 		})
-		g := snake.NewGame(s, cloak, sf)
+		g := snake.NewGame(s, clock, sf)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -154,8 +153,9 @@ func TestGame(t *testing.T) {
 		// skip init food coordinate send
 		snake.WaitAndReceiveGameChannels(t, g)
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
+		assertScore(t, receiveScore(t, g), 1)
 		c, r, fc := snake.WaitAndReceiveGameChannels(t, g)
 		assertNoGameResult(t, r)
 		assertNoFoodCoordinate(t, fc)
@@ -169,8 +169,7 @@ func TestGame(t *testing.T) {
 
 	t.Run("should generate food after snake eats", func(t *testing.T) {
 		s := snake.NewSnake(10, 10)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 		sf := &snake.FoodStub{}
 		sf.Seed([]snake.FoodStubValue{
 			{snake.Coordinate{5, 5}, nil},
@@ -178,7 +177,7 @@ func TestGame(t *testing.T) {
 			{snake.Coordinate{3, 5}, nil},
 			{snake.Coordinate{2, 5}, nil},
 		})
-		g := snake.NewGame(s, cloak, sf)
+		g := snake.NewGame(s, clock, sf)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -186,7 +185,7 @@ func TestGame(t *testing.T) {
 		// skip init food coordinate send
 		snake.WaitAndReceiveGameChannels(t, g)
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		c, r, fc := snake.WaitAndReceiveGameChannels(t, g)
 		assertNoGameResult(t, r)
@@ -198,7 +197,7 @@ func TestGame(t *testing.T) {
 		assertNoSnakeCoordinates(t, c)
 		snake.AssertCoordinate(t, *fc, snake.Coordinate{4, 5})
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		c, r, fc = snake.WaitAndReceiveGameChannels(t, g)
 		assertNoGameResult(t, r)
@@ -213,8 +212,7 @@ func TestGame(t *testing.T) {
 
 	t.Run("game should end with a win when snake fills the entire board", func(t *testing.T) {
 		s := snake.NewSnakeOfLength(2, 2, 1)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 		sf := &snake.FoodStub{}
 		sf.Seed([]snake.FoodStubValue{
 			{snake.Coordinate{0, 1}, nil},
@@ -222,7 +220,7 @@ func TestGame(t *testing.T) {
 			{snake.Coordinate{1, 0}, nil},
 			{snake.Coordinate{1, 1}, snake.ErrBoardFull},
 		})
-		g := snake.NewGame(s, cloak, sf)
+		g := snake.NewGame(s, clock, sf)
 		g.Start(time.Microsecond)
 
 		// skip init snake coordinates send
@@ -230,7 +228,7 @@ func TestGame(t *testing.T) {
 		// skip init food coordinate send
 		snake.WaitAndReceiveGameChannels(t, g)
 
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		c, r, fc := snake.WaitAndReceiveGameChannels(t, g)
 		assertNoGameResult(t, r)
@@ -243,7 +241,7 @@ func TestGame(t *testing.T) {
 		snake.AssertCoordinate(t, *fc, snake.Coordinate{0, 0})
 
 		g.SendMove(snake.Up)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		c, r, fc = snake.WaitAndReceiveGameChannels(t, g)
 		assertNoGameResult(t, r)
@@ -256,7 +254,7 @@ func TestGame(t *testing.T) {
 		snake.AssertCoordinate(t, *fc, snake.Coordinate{1, 0})
 
 		g.SendMove(snake.Right)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 
 		c, r, fc = snake.WaitAndReceiveGameChannels(t, g)
 		assertNoFoodCoordinate(t, fc)
@@ -282,10 +280,9 @@ func TestGame(t *testing.T) {
 		s := snake.NewSnake(width, height)
 		sf := &snake.FoodStub{}
 		sf.Seed(foodSeededCoordinates)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 
-		g := snake.NewGame(s, cloak, sf)
+		g := snake.NewGame(s, clock, sf)
 		g.Start(time.Microsecond)
 
 		wantSnakeCoord, _, _ := snake.WaitAndReceiveGameChannels(t, g)
@@ -301,47 +298,136 @@ func TestGame(t *testing.T) {
 		snake.AssertCoordinate(t, *gotFoodCoord, *wantFoodCoord)
 	})
 
+	t.Run("should resize board on restart", func(t *testing.T) {
+		s := snake.NewSnake(width, height)
+		sf := &snake.FoodStub{}
+		sf.Seed(foodSeededCoordinates)
+		clock := snake.NewFakeClock(time.Now())
+
+		g := snake.NewGame(s, clock, sf)
+		g.Start(time.Microsecond)
+
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		g.Resize(10, 10)
+		g.Restart(time.Microsecond)
+
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		want := []snake.Coordinate{{6, 5}, {7, 5}, {8, 5}}
+		snake.AssertCoordinates(t, got, want)
+		snake.WaitAndReceiveGameChannels(t, g)
+	})
+
+	t.Run("should not move snake while paused", func(t *testing.T) {
+		s := snake.NewSnake(width, height)
+		sf := &snake.FoodStub{}
+		sf.Seed(foodSeededCoordinates)
+		clock := snake.NewFakeClock(time.Now())
+
+		g := snake.NewGame(s, clock, sf)
+		g.Start(time.Microsecond)
+
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		g.Pause()
+		clock.Advance(time.Microsecond)
+		select {
+		case c := <-g.ReceiveSnakeCoordinates():
+			t.Fatalf("got snake coordinates %v while paused", c)
+		default:
+		}
+
+		g.Resume()
+		clock.Advance(time.Microsecond)
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		want := []snake.Coordinate{{35, 30}, {36, 30}, {37, 30}}
+		snake.AssertCoordinates(t, got, want)
+	})
+
+	t.Run("should continue a restored game like the saved one", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		saved := snake.NewGame(snake.NewSnake(10, 10), clock, snake.NewSeededFood(10, 10, 3))
+		saved.Start(time.Millisecond)
+		snake.WaitAndReceiveGameChannels(t, saved)
+		snake.WaitAndReceiveGameChannels(t, saved)
+		saved.SendMove(snake.Up)
+		clock.Advance(time.Millisecond)
+		snake.WaitAndReceiveGameChannels(t, saved)
+
+		state := saved.Save()
+		restoredClock := snake.NewFakeClock(time.Now())
+		restored := snake.NewGame(snake.NewSnake(60, 60), restoredClock, snake.NewFood(60, 60))
+		err := restored.Restore(state)
+		snake.AssertNoError(t, err)
+		restored.Start(time.Millisecond)
+
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, restored)
+		snake.AssertCoordinates(t, got, state.Snake)
+		_, _, food := snake.WaitAndReceiveGameChannels(t, restored)
+		snake.AssertCoordinate(t, *food, state.Food)
+		for i := 0; i < 4; i++ {
+			clock.Advance(time.Millisecond)
+			restoredClock.Advance(time.Millisecond)
+			want, _, _ := snake.WaitAndReceiveGameChannels(t, saved)
+			got, _, _ := snake.WaitAndReceiveGameChannels(t, restored)
+			snake.AssertCoordinates(t, got, want)
+		}
+		if got, want := restored.Save(), saved.Save(); !reflect.DeepEqual(got, want) {
+			t.Errorf("got state %+v, want %+v", got, want)
+		}
+		saved.Quit()
+		restored.Quit()
+	})
+
+	t.Run("should reject invalid restored states", func(t *testing.T) {
+		g := snake.NewGame(snake.NewSnake(10, 10), snake.NewFakeClock(time.Now()), fs)
+		state := newSaveState(t)
+		state.Food = state.Snake[0]
+
+		err := g.Restore(state)
+		snake.AssertError(t, err, snake.ErrInvalidSave)
+	})
+
 	t.Run("should quit game releasing resources", func(t *testing.T) {
 		s := snake.NewSnake(width, height)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 
-		g := snake.NewGame(s, cloak, fs)
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		snake.WaitAndReceiveGameChannels(t, g)
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		g.Quit()
+		clock.Advance(time.Microsecond)
 
-		select {
-		case cloak.C <- time.Now():
-			t.Error("should not be able to send ticks to game")
-		case <-time.After(time.Millisecond):
+		if _, ok := <-g.ReceiveSnakeCoordinates(); ok {
+			t.Error("should not have moved the snake after quit")
 		}
 	})
 
 	t.Run("game should end with a lose when snake head hits snake body", func(t *testing.T) {
 		s := snake.NewSnakeOfLength(width, height, 6)
-		cloak := NewStubCloak()
-		defer cloak.Stop()
+		clock := snake.NewFakeClock(time.Now())
 
-		g := snake.NewGame(s, cloak, fs)
+		g := snake.NewGame(s, clock, fs)
 		g.Start(time.Microsecond)
 
 		snake.WaitAndReceiveGameChannels(t, g)
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		g.SendMove(snake.Up)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		g.SendMove(snake.Right)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 		snake.WaitAndReceiveGameChannels(t, g)
 
 		g.SendMove(snake.Down)
-		cloak.AddTick()
+		clock.Advance(time.Microsecond)
 		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
 
 		assertGameResult(t, r, false)
@@ -377,6 +463,18 @@ func assertSnakeLength(t testing.TB, c []snake.Coordinate, want int) {
 	}
 }
 
+func receiveScore(t testing.TB, g *snake.Game) int {
+	t.Helper()
+	return <-g.ReceiveScore()
+}
+
+func assertScore(t testing.TB, got, want int) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got score %d, want %d", got, want)
+	}
+}
+
 func assertGameResult(t testing.TB, got *bool, want bool) {
 	t.Helper()
 	if got == nil {
@@ -388,30 +486,220 @@ func assertGameResult(t testing.TB, got *bool, want bool) {
 	}
 }
 
-type StubCloak struct {
-	C        chan time.Time
-	now      time.Time
-	i        int
-	duration time.Duration
-}
+func TestGameLifecycle(t *testing.T) {
+	width, height := 60, 60
+	newFood := func() *snake.FoodStub {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}, {snake.Coordinate{1, 0}, nil}})
+		return fs
+	}
 
-func NewStubCloak() *StubCloak {
-	ticker := make(chan time.Time)
-	return &StubCloak{ticker, time.Now(), 0, time.Nanosecond}
-}
+	t.Run("should quit while sending the snake coordinates", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		g.Start(time.Microsecond)
 
-func (c *StubCloak) Start(d time.Duration) {
-	c.duration = d
-}
+		g.Quit()
 
-func (c *StubCloak) AddTick() {
-	c.C <- c.now.Add(time.Duration(c.i) * c.duration)
-}
+		if _, ok := <-g.ReceiveSnakeCoordinates(); ok {
+			t.Error("should have closed the snake coordinates channel")
+		}
+		if _, ok := <-g.ReceiveScore(); ok {
+			t.Error("should have closed the score channel")
+		}
+		g.SendMove(snake.Up)
+		g.Restart(time.Microsecond)
+	})
+
+	t.Run("should receive moves while sending the snake coordinates", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		defer g.Quit()
+		g.Start(time.Microsecond)
+
+		g.SendMove(snake.Up)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		want := []snake.Coordinate{{36, 29}, {36, 30}, {37, 30}}
+		snake.AssertCoordinates(t, got, want)
+	})
+
+	t.Run("should restart while sending the snake coordinates", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		defer g.Quit()
+		g.Start(time.Microsecond)
+
+		g.Restart(time.Microsecond)
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.AssertCoordinates(t, got, snake.NewSnake(width, height).GetCoordinates())
+	})
+
+	t.Run("should quit when the context is canceled", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error)
+		go func() {
+			errC <- g.Run(ctx, time.Microsecond)
+		}()
+
+		snake.WaitAndReceiveGameChannels(t, g)
+		cancel()
+		snake.AssertError(t, <-errC, context.Canceled)
+		if _, ok := <-g.ReceiveFoodCoordinate(); ok {
+			t.Error("should have closed the food coordinate channel")
+		}
+	})
 
-func (c *StubCloak) Tick() <-chan time.Time {
-	return c.C
+	t.Run("should return from Run when the game quits", func(t *testing.T) {
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		errC := make(chan error)
+		go func() {
+			errC <- g.Run(context.Background(), time.Microsecond)
+		}()
+
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Quit()
+		snake.AssertNoError(t, <-errC)
+	})
+
+	t.Run("should start once", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		defer g.Quit()
+
+		g.Start(time.Microsecond)
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameEvent(t, g)
+	})
+
+	t.Run("should start on Restart before Start", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, newFood())
+		defer g.Quit()
+
+		g.Restart(time.Microsecond)
+		got, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.AssertCoordinates(t, got, snake.NewSnake(width, height).GetCoordinates())
+	})
+
+	t.Run("should not leak go routines after restarts and quit", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		clock := snake.NewFakeClock(time.Now())
+		fs := &snake.FoodStub{}
+		values := make([]snake.FoodStubValue, 10)
+		for i := range values {
+			values[i] = snake.FoodStubValue{Coord: snake.Coordinate{X: i, Y: 0}}
+		}
+		fs.Seed(values)
+		g := snake.NewGame(snake.NewSnake(width, height), clock, fs)
+
+		g.Start(time.Microsecond)
+		for i := 0; i < 5; i++ {
+			g.Restart(time.Microsecond)
+		}
+		g.Quit()
+		g.Quit()
+	})
+
+	t.Run("should be safe to use from many go routines", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(width, height), clock, snake.NewSeededFood(width, height, 1))
+
+		var wg sync.WaitGroup
+		consumed := make(chan struct{})
+		go func() {
+			defer close(consumed)
+			snakeC, foodC, resultC := g.ReceiveSnakeCoordinates(), g.ReceiveFoodCoordinate(), g.ReceiveGameResult()
+			for snakeC != nil || foodC != nil || resultC != nil {
+				select {
+				case _, ok := <-snakeC:
+					if !ok {
+						snakeC = nil
+					}
+				case _, ok := <-foodC:
+					if !ok {
+						foodC = nil
+					}
+				case _, ok := <-resultC:
+					if !ok {
+						resultC = nil
+					}
+				}
+			}
+		}()
+		tickerDone := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-tickerDone:
+					return
+				default:
+					clock.Advance(time.Microsecond)
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		g.Start(time.Microsecond)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					switch (i + j) % 6 {
+					case 0:
+						g.Restart(time.Microsecond)
+					case 1:
+						g.SendMove(snake.Direction(1 << (j % 4)))
+					case 2:
+						g.Pause()
+					case 3:
+						g.Resume()
+					case 4:
+						g.Save()
+					case 5:
+						g.Resize(width, height)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				g.Quit()
+			}()
+		}
+		wg.Wait()
+		close(tickerDone)
+		<-consumed
+		g.Start(time.Microsecond)
+		g.Restart(time.Microsecond)
+		g.SendMove(snake.Up)
+	})
 }
 
-func (c *StubCloak) Stop() {
-	close(c.C)
+// assertNoGameEvent asserts that the game has no event ready on its channels.
+func assertNoGameEvent(t testing.TB, g *snake.Game) {
+	t.Helper()
+	select {
+	case c := <-g.ReceiveSnakeCoordinates():
+		t.Errorf("got snake coordinates %v, want no game event", c)
+	case c := <-g.ReceiveFoodCoordinate():
+		t.Errorf("got food coordinate %v, want no game event", c)
+	case r := <-g.ReceiveGameResult():
+		t.Errorf("got game result %v, want no game event", r)
+	default:
+	}
 }
@@ -0,0 +1,113 @@
+package snake
+
+import "github.com/gdamore/tcell/v2"
+
+// Glyphs defines the runes used to draw the snake segments.
+//
+// Head maps the snake face direction to the head rune.
+//
+// Body maps the union of the directions towards the previous and the next
+// segment to the body rune: Left|Right is an horizontal segment,
+// Up|Down is a vertical segment and any other pair is a corner.
+//
+// Tail maps the direction towards the previous segment to the tail rune.
+//
+// Fallback is drawn when a segment direction can not be computed.
+type Glyphs struct {
+	Head     map[Direction]rune
+	Body     map[Direction]rune
+	Tail     map[Direction]rune
+	Fallback rune
+}
+
+// UnicodeGlyphs draws the snake with box-drawing characters.
+var UnicodeGlyphs = Glyphs{
+	Head: map[Direction]rune{
+		Up:    '▲',
+		Down:  '▼',
+		Left:  '◀',
+		Right: '▶',
+	},
+	Body: map[Direction]rune{
+		Left | Right: '━',
+		Up | Down:    '┃',
+		Down | Right: '┏',
+		Down | Left:  '┓',
+		Up | Right:   '┗',
+		Up | Left:    '┛',
+	},
+	Tail: map[Direction]rune{
+		Up:    '╹',
+		Down:  '╻',
+		Left:  '╸',
+		Right: '╺',
+	},
+	Fallback: BodyRune,
+}
+
+// ASCIIGlyphs draws the snake with plain ASCII characters,
+// for terminals which can not display UnicodeGlyphs.
+var ASCIIGlyphs = Glyphs{
+	Head: map[Direction]rune{
+		Up:    '^',
+		Down:  'v',
+		Left:  '<',
+		Right: '>',
+	},
+	Body: map[Direction]rune{
+		Left | Right: '-',
+		Up | Down:    '|',
+		Down | Right: '+',
+		Down | Left:  '+',
+		Up | Right:   '+',
+		Up | Left:    '+',
+	},
+	Tail: map[Direction]rune{
+		Up:    'o',
+		Down:  'o',
+		Left:  'o',
+		Right: 'o',
+	},
+	Fallback: '#',
+}
+
+// Segment returns the rune which draws the i-th segment of the snake
+// coordinates c, facing face. The head glyph follows face, or the direction
+// from the second segment to the head if face is zero. The segments next
+// to portals point towards the portal the snake moves through.
+func (g Glyphs) Segment(c []Coordinate, i int, face Direction, portals ...Portal) rune {
+	var r rune
+	var ok bool
+	switch {
+	case len(c) < 2:
+		return g.Fallback
+	case i == 0 && face != 0:
+		r, ok = g.Head[face]
+	case i == 0:
+		r, ok = g.Head[link(portals, c[1], c[0])]
+	case i == len(c)-1:
+		r, ok = g.Tail[link(portals, c[i], c[i-1])]
+	default:
+		r, ok = g.Body[link(portals, c[i], c[i-1])|opposite(link(portals, c[i+1], c[i]))]
+	}
+	if !ok {
+		return g.Fallback
+	}
+	return r
+}
+
+// canDisplay returns true if screen can display every rune of g.
+func (g Glyphs) canDisplay(screen tcell.Screen) bool {
+	runes := []rune{g.Fallback}
+	for _, m := range []map[Direction]rune{g.Head, g.Body, g.Tail} {
+		for _, r := range m {
+			runes = append(runes, r)
+		}
+	}
+	for _, r := range runes {
+		if !screen.CanDisplay(r, false) {
+			return false
+		}
+	}
+	return true
+}
@@ -2,4 +2,7 @@ module github.com/castagnadaniele/go-snake
 
 go 1.16
 
-require github.com/gdamore/tcell/v2 v2.4.0
+require (
+	github.com/gdamore/tcell/v2 v2.4.0
+	github.com/mattn/go-runewidth v0.0.10
+)
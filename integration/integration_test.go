@@ -1,16 +1,18 @@
 package integration_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/castagnadaniele/go-snake"
+	"github.com/castagnadaniele/go-snake/internal/supervisor"
+	"github.com/gdamore/tcell/v2"
 )
 
 func TestGameTicker(t *testing.T) {
 	t.Run("should run game", func(t *testing.T) {
 		s := snake.NewSnake(60, 60)
-		cloak := snake.NewCloak()
 		f := &snake.FoodStub{}
 		f.Seed([]snake.FoodStubValue{
 			{Coord: snake.Coordinate{X: 0, Y: 0}, Err: nil},
@@ -18,14 +20,10 @@ func TestGameTicker(t *testing.T) {
 			{Coord: snake.Coordinate{X: 2, Y: 0}, Err: nil},
 			{Coord: snake.Coordinate{X: 3, Y: 0}, Err: nil},
 		})
-		g := snake.NewGame(s, cloak, f)
+		g := snake.NewGame(s, snake.RealClock{}, f)
+		defer g.Quit()
 
-		g.Start(2 * time.Millisecond)
-
-		go func() {
-			<-time.After(5 * time.Millisecond)
-			cloak.Stop()
-		}()
+		g.Start(time.Millisecond)
 
 		// skip init snake coordinates send
 		snake.WaitAndReceiveGameChannels(t, g)
@@ -42,3 +40,34 @@ func TestGameTicker(t *testing.T) {
 		snake.AssertCoordinates(t, got, want)
 	})
 }
+
+func TestShutdown(t *testing.T) {
+	t.Run("should stop all go routines when the context is canceled", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		screen := tcell.NewSimulationScreen("UTF-8")
+		if err := screen.Init(); err != nil {
+			t.Fatal(err)
+		}
+		screen.SetSize(40, 20)
+		view := snake.NewView(screen)
+		defer view.Release()
+		clock := snake.NewFakeClock(time.Now())
+		game := snake.NewGame(snake.NewSnake(30, 15), clock, snake.NewFood(30, 15))
+		controller := snake.NewController(game, view)
+		controller.SetClock(clock)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		group, ctx := supervisor.WithContext(ctx)
+		group.Go(func() error {
+			return controller.Run(ctx, time.Millisecond)
+		})
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+		clock.Advance(time.Millisecond)
+		cancel()
+
+		err := group.Wait()
+		if err != context.Canceled {
+			t.Errorf("got error %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+// Package supervisor runs a group of go routines which stop together,
+// like an errgroup: the first go routine to return cancels the context
+// of the others, so one failing or finishing part stops the whole program.
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is the error returned by Wait when a go routine panics.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("supervisor: panic: %v\n%s", e.Value, e.Stack)
+}
+
+// Group is a group of supervised go routines.
+type Group struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// WithContext returns a new Group and a context derived from ctx,
+// which is canceled when the first go routine started by Go returns
+// or when Wait returns, whichever occurs first.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go calls f in a new go routine. A panic in f is recovered
+// and returned by Wait as a *PanicError.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		var err error
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{r, debug.Stack()}
+			}
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}()
+		err = f()
+	}()
+}
+
+// Wait blocks until all the go routines started by Go return, then returns
+// the error of the first one which returned. It is nil if that go routine
+// returned nil, even if the others, stopped by the context cancellation,
+// returned an error.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,74 @@
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake/internal/supervisor"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("should cancel the others when the first returns", func(t *testing.T) {
+		group, ctx := supervisor.WithContext(context.Background())
+		group.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		group.Go(func() error {
+			return nil
+		})
+		assertWait(t, group, nil)
+	})
+
+	t.Run("should return the first error", func(t *testing.T) {
+		want := errors.New("failed")
+		group, ctx := supervisor.WithContext(context.Background())
+		group.Go(func() error {
+			<-ctx.Done()
+			return errors.New("stopped")
+		})
+		group.Go(func() error {
+			return want
+		})
+		assertWait(t, group, want)
+	})
+
+	t.Run("should stop when the parent context is canceled", func(t *testing.T) {
+		parent, cancel := context.WithCancel(context.Background())
+		group, ctx := supervisor.WithContext(parent)
+		group.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		cancel()
+		assertWait(t, group, context.Canceled)
+	})
+
+	t.Run("should recover a panic", func(t *testing.T) {
+		group, ctx := supervisor.WithContext(context.Background())
+		group.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+		group.Go(func() error {
+			panic("boom")
+		})
+		err := group.Wait()
+		var panicErr *supervisor.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("got error %v, want a panic error", err)
+		}
+		if panicErr.Value != "boom" {
+			t.Errorf("got panic value %v, want %q", panicErr.Value, "boom")
+		}
+	})
+}
+
+func assertWait(t testing.TB, group *supervisor.Group, want error) {
+	t.Helper()
+	err := group.Wait()
+	if err != want {
+		t.Errorf("got error %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,368 @@
+// Package websocket implements the subset of the WebSocket protocol
+// (RFC 6455) needed to exchange text messages with a browser:
+// the server handshake, a client handshake for tests and tools,
+// text, ping, pong and close frames.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	ErrBadHandshake    = Error("websocket: bad handshake")
+	ErrProtocol        = Error("websocket: protocol error")
+	ErrMessageTooLarge = Error("websocket: message too large")
+	ErrClosed          = Error("websocket: connection closed")
+	ErrBadOrigin       = Error("websocket: origin not allowed")
+)
+
+// Error type defines websocket errors.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// MaxMessageSize is the largest message, in bytes, which a Conn reads.
+const MaxMessageSize = 1 << 20
+
+// WriteTimeout is how long a frame write can block before failing,
+// so that a stalled peer does not block the other writers forever.
+const WriteTimeout = 10 * time.Second
+
+// CloseTimeout is how long Close waits to send the close frame
+// before closing the connection.
+const CloseTimeout = time.Second
+
+// acceptGUID is appended to the handshake key to compute the accept key.
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	continuationFrame = 0x0
+	textFrame         = 0x1
+	binaryFrame       = 0x2
+	closeFrame        = 0x8
+	pingFrame         = 0x9
+	pongFrame         = 0xa
+)
+
+// Conn is a WebSocket connection. ReadMessage should be called
+// by a single go routine, while WriteMessage and Close are safe
+// for concurrent use.
+type Conn struct {
+	conn      net.Conn
+	reader    *bufio.Reader
+	client    bool
+	writeMu   sync.Mutex
+	closing   int32
+	closeOnce sync.Once
+}
+
+// Upgrade upgrades the HTTP server request r to a WebSocket connection.
+// If the request is not a valid WebSocket handshake it replies with a
+// bad request error and returns ErrBadHandshake. If the request Origin
+// host differs from the request Host and is not one of allowedOrigins,
+// it replies with a forbidden error and returns ErrBadOrigin, so that
+// other sites can not open connections from their visitors browsers.
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigins ...string) (*Conn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		http.Error(w, ErrBadHandshake.Error(), http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+	if !checkOrigin(r, allowedOrigins) {
+		http.Error(w, ErrBadOrigin.Error(), http.StatusForbidden)
+		return nil, ErrBadOrigin
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, ErrBadHandshake.Error(), http.StatusInternalServerError)
+		return nil, ErrBadHandshake
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, reader: rw.Reader}, nil
+}
+
+// Dial opens a client WebSocket connection to rawURL, which should
+// have the ws scheme.
+func Dial(rawURL string) (*Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" {
+		return nil, ErrBadHandshake
+	}
+	conn, err := net.Dial("tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	req, err := http.NewRequest(http.MethodGet, "http://"+u.Host+u.RequestURI(), nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		conn.Close()
+		return nil, ErrBadHandshake
+	}
+	return &Conn{conn: conn, reader: reader, client: true}, nil
+}
+
+// ReadMessage reads the next text or binary message, answering pings
+// and joining fragmented frames. When the peer closes the connection it
+// replies with a close frame and returns io.EOF.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	fragmented := false
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case pingFrame:
+			if err := c.writeFrame(pongFrame, payload, time.Now().Add(WriteTimeout)); err != nil {
+				return nil, err
+			}
+			continue
+		case pongFrame:
+			continue
+		case closeFrame:
+			c.writeFrame(closeFrame, nil, time.Now().Add(CloseTimeout))
+			c.conn.Close()
+			return nil, io.EOF
+		case textFrame, binaryFrame:
+			if fragmented {
+				return nil, ErrProtocol
+			}
+		case continuationFrame:
+			if !fragmented {
+				return nil, ErrProtocol
+			}
+		default:
+			return nil, ErrProtocol
+		}
+		if len(message)+len(payload) > MaxMessageSize {
+			return nil, ErrMessageTooLarge
+		}
+		message = append(message, payload...)
+		if fin {
+			return message, nil
+		}
+		fragmented = true
+	}
+}
+
+// WriteMessage writes data as a text message. It fails if the message
+// is not written within WriteTimeout, or if the connection is closing.
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(textFrame, data, time.Now().Add(WriteTimeout))
+}
+
+// Close sends a close frame and closes the connection. The close frame is
+// sent on a best-effort basis: the writes in progress and the close frame
+// write fail after CloseTimeout. It can be called more than once.
+func (c *Conn) Close() error {
+	var err error = ErrClosed
+	c.closeOnce.Do(func() {
+		atomic.StoreInt32(&c.closing, 1)
+		deadline := time.Now().Add(CloseTimeout)
+		c.conn.SetWriteDeadline(deadline)
+		c.writeFrame(closeFrame, nil, deadline)
+		err = c.conn.Close()
+	})
+	return err
+}
+
+// readFrame reads a frame, unmasking its payload. Frames sent by
+// clients must be masked, frames sent by servers must not.
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err = io.ReadFull(c.reader, header[:]); err != nil {
+		return
+	}
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0f
+	if header[0]&0x70 != 0 {
+		err = ErrProtocol
+		return
+	}
+	masked := header[1]&0x80 != 0
+	if masked == c.client {
+		err = ErrProtocol
+		return
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err = io.ReadFull(c.reader, extended[:]); err != nil {
+			return
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err = io.ReadFull(c.reader, extended[:]); err != nil {
+			return
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if opcode >= closeFrame && (length > 125 || !fin) {
+		err = ErrProtocol
+		return
+	}
+	if length > MaxMessageSize {
+		err = ErrMessageTooLarge
+		return
+	}
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.reader, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.reader, payload); err != nil {
+		return
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return
+}
+
+// writeFrame writes a single final frame before deadline, masking its
+// payload if c is a client connection. Once the connection is closing
+// only the close frame is written.
+func (c *Conn) writeFrame(opcode byte, payload []byte, deadline time.Time) error {
+	frame := []byte{0x80 | opcode}
+	maskBit := byte(0)
+	if c.client {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, maskBit|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(length))
+	default:
+		frame = append(frame, maskBit|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(length))
+	}
+	if c.client {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		for i := range frame[start:] {
+			frame[start+i] ^= mask[i%4]
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if opcode != closeFrame && atomic.LoadInt32(&c.closing) != 0 {
+		return ErrClosed
+	}
+	if err := c.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// acceptKey returns the Sec-WebSocket-Accept header value for key.
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerContains returns true if the comma separated header name
+// contains token, ignoring case.
+func headerContains(header http.Header, name, token string) bool {
+	for _, v := range header.Values(name) {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkOrigin returns true if the request r has no Origin header, as
+// requests not sent by browsers, if the Origin host matches the request
+// Host or if the Origin is one of allowedOrigins.
+func checkOrigin(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range allowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
@@ -0,0 +1,180 @@
+package websocket_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake/internal/websocket"
+)
+
+func TestWebSocket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(message); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	for _, size := range []int{0, 5, 125, 126, 65535, 65536} {
+		t.Run("should echo message", func(t *testing.T) {
+			conn, err := websocket.Dial(url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			want := bytes.Repeat([]byte("s"), size)
+
+			if err := conn.WriteMessage(want); err != nil {
+				t.Fatal(err)
+			}
+			got, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("got message of %d bytes, want %d bytes", len(got), len(want))
+			}
+		})
+	}
+
+	t.Run("should return EOF after the peer closes the connection", func(t *testing.T) {
+		serverConn := make(chan *websocket.Conn, 1)
+		closing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := websocket.Upgrade(w, r)
+			if err != nil {
+				return
+			}
+			serverConn <- conn
+		}))
+		defer closing.Close()
+		client, err := websocket.Dial("ws" + strings.TrimPrefix(closing.URL, "http"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		(<-serverConn).Close()
+
+		if _, err := client.ReadMessage(); err != io.EOF {
+			t.Errorf("got error %v, want %v", err, io.EOF)
+		}
+	})
+
+	t.Run("should close while writing to a peer which does not read", func(t *testing.T) {
+		serverConn := make(chan *websocket.Conn, 1)
+		stalling := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := websocket.Upgrade(w, r)
+			if err != nil {
+				return
+			}
+			serverConn <- conn
+		}))
+		defer stalling.Close()
+		client, err := websocket.Dial("ws" + strings.TrimPrefix(stalling.URL, "http"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+		conn := <-serverConn
+
+		wroteC := make(chan struct{}, 1)
+		errC := make(chan error)
+		go func() {
+			message := bytes.Repeat([]byte("s"), websocket.MaxMessageSize)
+			for {
+				if err := conn.WriteMessage(message); err != nil {
+					errC <- err
+					return
+				}
+				select {
+				case wroteC <- struct{}{}:
+				default:
+				}
+			}
+		}()
+		<-wroteC
+		conn.Close()
+
+		if err := <-errC; err == nil {
+			t.Error("should have failed writing after closing")
+		}
+	})
+
+	t.Run("should reject requests which are not websocket handshakes", func(t *testing.T) {
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("should reject handshakes from other origins", func(t *testing.T) {
+		allowing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conn, err := websocket.Upgrade(w, r, "http://allowed.example")
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}))
+		defer allowing.Close()
+		cases := []struct {
+			origin string
+			want   int
+		}{
+			{"", http.StatusSwitchingProtocols},
+			{allowing.URL, http.StatusSwitchingProtocols},
+			{"http://allowed.example", http.StatusSwitchingProtocols},
+			{"http://evil.example", http.StatusForbidden},
+		}
+		for _, c := range cases {
+			req, err := http.NewRequest(http.MethodGet, allowing.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			if c.origin != "" {
+				req.Header.Set("Origin", c.origin)
+			}
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != c.want {
+				t.Errorf("got status %d with origin %q, want %d", resp.StatusCode, c.origin, c.want)
+			}
+		}
+	})
+
+	t.Run("should return error dialing a server which does not upgrade", func(t *testing.T) {
+		plain := httptest.NewServer(http.NotFoundHandler())
+		defer plain.Close()
+
+		_, err := websocket.Dial("ws" + strings.TrimPrefix(plain.URL, "http"))
+		if err != websocket.ErrBadHandshake {
+			t.Errorf("got error %v, want %v", err, websocket.ErrBadHandshake)
+		}
+	})
+}
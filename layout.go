@@ -0,0 +1,125 @@
+package snake
+
+import (
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const FrameColor = tcell.ColorGray
+
+// frameCols and frameRows are the screen columns and rows around
+// the board taken by the frame and by the status bar.
+const (
+	frameCols = 2
+	frameRows = 3
+)
+
+// fitBoard returns the board width and height which fit in the screen
+// inside the frame with the view renderer.
+func (v *View) fitBoard() (int, int) {
+	cols, rows := v.screen.Size()
+	return v.renderer.BoardSize(cols-frameCols, rows-frameRows)
+}
+
+// board returns the board width and height drawn inside the frame.
+func (v *View) board() (int, int) {
+	if v.boardWidth == 0 && v.boardHeight == 0 {
+		return v.fitBoard()
+	}
+	return v.boardWidth, v.boardHeight
+}
+
+// frameSize returns the screen columns and rows taken by the viewport,
+// its frame and the status bar.
+func (v *View) frameSize() (int, int) {
+	cols, rows := v.renderer.ScreenSize(v.viewport())
+	return cols + frameCols, rows + frameRows
+}
+
+// minFrameSize returns the screen columns and rows taken by the smallest
+// viewport, its frame and the status bar.
+func (v *View) minFrameSize() (int, int) {
+	width, height := v.board()
+	cols, rows := v.renderer.ScreenSize(minInt(width, MinViewportWidth), minInt(height, MinViewportHeight))
+	return cols + frameCols, rows + frameRows
+}
+
+// fits returns true if the smallest viewport frame fits in the screen.
+func (v *View) fits() bool {
+	cols, rows := v.minFrameSize()
+	width, height := v.screen.Size()
+	return cols <= width && rows <= height
+}
+
+// origin returns the screen cell on which the viewport top left corner is drawn,
+// centering the frame on the screen.
+func (v *View) origin() (int, int) {
+	cols, rows := v.frameSize()
+	width, height := v.screen.Size()
+	x, y := (width-cols)/2, (height-rows)/2
+	if x < 0 {
+		x = 0
+	}
+	if y < 0 {
+		y = 0
+	}
+	return x + 1, y + 1
+}
+
+// toScreen returns the screen cell on which the board coordinate c is drawn.
+// Coordinates out of the viewport are translated to screen cells out of the frame.
+func (v *View) toScreen(c Coordinate) (int, int) {
+	x, y := v.renderer.ScreenCell(Coordinate{c.X - v.camera.X, c.Y - v.camera.Y})
+	originX, originY := v.origin()
+	return originX + x, originY + y
+}
+
+func (v *View) drawFrame() {
+	cols, rows := v.renderer.ScreenSize(v.viewport())
+	originX, originY := v.origin()
+	left, top, right, bottom := originX-1, originY-1, originX+cols, originY+rows
+	style := tcell.StyleDefault.Foreground(FrameColor)
+	for x := left + 1; x < right; x++ {
+		v.screen.SetContent(x, top, tcell.RuneHLine, nil, style)
+		v.screen.SetContent(x, bottom, tcell.RuneHLine, nil, style)
+	}
+	for y := top + 1; y < bottom; y++ {
+		v.screen.SetContent(left, y, tcell.RuneVLine, nil, style)
+		v.screen.SetContent(right, y, tcell.RuneVLine, nil, style)
+	}
+	v.screen.SetContent(left, top, tcell.RuneULCorner, nil, style)
+	v.screen.SetContent(right, top, tcell.RuneURCorner, nil, style)
+	v.screen.SetContent(left, bottom, tcell.RuneLLCorner, nil, style)
+	v.screen.SetContent(right, bottom, tcell.RuneLRCorner, nil, style)
+}
+
+// drawStatus draws the status bar below the frame, aligned to the frame left
+// side or moved to the left if the status would not fit in the screen.
+func (v *View) drawStatus() {
+	_, rows := v.renderer.ScreenSize(v.viewport())
+	originX, originY := v.origin()
+	y := originY + rows + 1
+	width, _ := v.screen.Size()
+	for i := 0; i < width; i++ {
+		v.screen.SetContent(i, y, ' ', nil, tcell.StyleDefault)
+	}
+	if v.status == nil {
+		return
+	}
+	status := v.status.String()
+	x := originX - 1
+	if overflow := x + utf8.RuneCountInString(status) - width; overflow > 0 {
+		x -= overflow
+	}
+	if x < 0 {
+		x = 0
+	}
+	for _, c := range status {
+		if x >= width {
+			return
+		}
+		v.screen.SetContent(x, y, c, nil, tcell.StyleDefault)
+		x++
+	}
+}
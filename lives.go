@@ -0,0 +1,52 @@
+package snake
+
+// RespawnInvulnerability is the number of ticks during which a respawned
+// snake can not be hit by blocks and hazards.
+const RespawnInvulnerability = 10
+
+// respawnClearance is the number of free cells ahead of a respawned snake head.
+const respawnClearance = 3
+
+// Life is the lives state of a game: the lives Left, including the one
+// being played, and the ticks left during which the respawned snake is
+// Invulnerable.
+type Life struct {
+	Left         int
+	Invulnerable int
+}
+
+// respawnCoordinates returns the coordinates of a snake of length facing left
+// inside the bounds b, off the occupied coordinates and with free cells
+// ahead of its head. The start coordinates are tried first, then the rows
+// from the top. It returns false if there are no such coordinates.
+func respawnCoordinates(start []Coordinate, length int, b Bounds, occupied []Coordinate) ([]Coordinate, bool) {
+	fits := func(head Coordinate) bool {
+		if head.X-respawnClearance < b.Min.X || head.X+length-1 > b.Max.X || head.Y < b.Min.Y || head.Y > b.Max.Y {
+			return false
+		}
+		for x := head.X - respawnClearance; x < head.X+length; x++ {
+			if contains(occupied, Coordinate{x, head.Y}) {
+				return false
+			}
+		}
+		return true
+	}
+	line := func(head Coordinate) []Coordinate {
+		c := make([]Coordinate, length)
+		for i := range c {
+			c[i] = Coordinate{head.X + i, head.Y}
+		}
+		return c
+	}
+	if len(start) > 0 && fits(start[0]) {
+		return line(start[0]), true
+	}
+	for y := b.Min.Y; y <= b.Max.Y; y++ {
+		for x := b.Min.X + respawnClearance; x+length-1 <= b.Max.X; x++ {
+			if fits(Coordinate{x, y}) {
+				return line(Coordinate{x, y}), true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,200 @@
+package snake_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestLives(t *testing.T) {
+	// startGame starts a game with a block in front of the snake head and
+	// lives, skipping the first lives, entities, snake and food coordinates sent.
+	startGame := func(t *testing.T, lives int) (*snake.Game, *snake.FakeClock, snake.Entity) {
+		t.Helper()
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+		t.Cleanup(g.Quit)
+		block := snake.Entity{Kind: snake.Block, Position: snake.Coordinate{35, 30}, Path: []snake.Coordinate{{35, 30}}}
+		snake.AssertNoError(t, g.SetEntities([]snake.Entity{block}))
+		g.SetRules(snake.Rules{Mode: snake.DefaultMode, Lives: lives})
+		g.Start(time.Microsecond)
+		assertLife(t, receiveLife(t, g), snake.Life{Left: lives})
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		return g, clock, block
+	}
+
+	t.Run("should respawn the snake away from the blocks when it dies with lives left", func(t *testing.T) {
+		g, clock, block := startGame(t, 2)
+
+		clock.Advance(time.Microsecond)
+		assertLife(t, receiveLife(t, g), snake.Life{Left: 1, Invulnerable: snake.RespawnInvulnerability})
+		receiveEntities(t, g)
+		sc, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+		assertSnakeLength(t, sc, 3)
+		for _, c := range sc {
+			if c == block.Position {
+				t.Errorf("got snake %v respawned on the block %v", sc, block.Position)
+			}
+		}
+
+		clock.Advance(time.Microsecond)
+		assertLife(t, receiveLife(t, g), snake.Life{Left: 1, Invulnerable: snake.RespawnInvulnerability - 1})
+		receiveEntities(t, g)
+		_, r, _ = snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+	})
+
+	t.Run("should lose the game when the lives are over", func(t *testing.T) {
+		g, clock, _ := startGame(t, 2)
+		clock.Advance(time.Microsecond)
+		receiveLife(t, g)
+		receiveEntities(t, g)
+		sc, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		if sc[0].Y != 0 {
+			t.Fatalf("got snake %v respawned, want it respawned on the top row", sc)
+		}
+
+		g.SendMove(snake.Up)
+		clock.Advance(time.Microsecond)
+		assertLife(t, receiveLife(t, g), snake.Life{Left: 0})
+		receiveEntities(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, false)
+	})
+
+	t.Run("should save and restore the lives", func(t *testing.T) {
+		g, clock, _ := startGame(t, 3)
+		clock.Advance(time.Microsecond)
+		receiveLife(t, g)
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+
+		s := g.Save()
+		if s.Lives != 2 || s.Invulnerable != snake.RespawnInvulnerability {
+			t.Fatalf("got %d lives and %d invulnerable ticks, want 2 lives and %d invulnerable ticks", s.Lives, s.Invulnerable, snake.RespawnInvulnerability)
+		}
+		restored := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer restored.Quit()
+		snake.AssertNoError(t, restored.Restore(s))
+		if got := restored.Save(); got.Lives != s.Lives || got.Invulnerable != s.Invulnerable {
+			t.Errorf("got %d lives and %d invulnerable ticks after restoring, want %d and %d", got.Lives, got.Invulnerable, s.Lives, s.Invulnerable)
+		}
+	})
+
+	t.Run("controller should keep the lives and the rules of the resumed game", func(t *testing.T) {
+		g, clock, _ := startGame(t, 3)
+		clock.Advance(time.Microsecond)
+		receiveLife(t, g)
+		receiveEntities(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+		s := g.Save()
+
+		game := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		view := NewViewSpy()
+		controller := snake.NewController(game, view)
+		snake.AssertNoError(t, controller.Restore(s))
+		ctx, cancel := context.WithCancel(context.Background())
+		errC := make(chan error)
+		go func() {
+			errC <- controller.Run(ctx, time.Microsecond)
+		}()
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if status := view.GetStatus(t); status.Lives != 2 || !status.HasLives {
+			t.Errorf("got %d lives in the status of the resumed game, want 2", status.Lives)
+		}
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		view.GetStatus(t)
+		cancel()
+		snake.AssertError(t, <-errC, context.Canceled)
+
+		got := game.Save()
+		if got.Lives != 2 {
+			t.Errorf("got %d lives after resuming, want 2", got.Lives)
+		}
+		if got.Rules != s.Rules {
+			t.Errorf("got rules %+v after resuming, want %+v", got.Rules, s.Rules)
+		}
+	})
+
+	t.Run("controller should display the lives and keep the score on respawn", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		controller.SetLives(3)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		<-game.StartC
+		if game.Rules.Lives != 3 {
+			t.Errorf("got %d lives set on the game, want 3", game.Rules.Lives)
+		}
+
+		long := []snake.Coordinate{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}}
+		game.SendLife(t, snake.Life{Left: 3})
+		game.SendSnakeCoordinates(t, long)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if status := view.GetStatus(t); status.Lives != 3 {
+			t.Errorf("got %d lives in the status, want 3", status.Lives)
+		}
+		<-view.InvulnerableC
+		game.SendScore(t, 1)
+
+		game.SendLife(t, snake.Life{Left: 2, Invulnerable: snake.RespawnInvulnerability})
+		game.SendSnakeCoordinates(t, long[:3])
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		status := view.GetStatus(t)
+		assertStatus(t, status, 1, 3)
+		if status.Lives != 2 {
+			t.Errorf("got %d lives in the status after respawning, want 2", status.Lives)
+		}
+		ticks := <-view.InvulnerableC
+		if ticks != snake.RespawnInvulnerability {
+			t.Errorf("got %d invulnerable ticks set on the view, want %d", ticks, snake.RespawnInvulnerability)
+		}
+
+		game.SendLife(t, snake.Life{Left: 0})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if status := view.GetStatus(t); status.Lives != 0 || !status.HasLives {
+			t.Errorf("got %d lives in the status after losing the last life, want 0", status.Lives)
+		}
+	})
+
+	t.Run("status should display the lives left", func(t *testing.T) {
+		s := snake.Status{Mode: snake.DefaultMode, Lives: 2, HasLives: true}
+		want := "Score: 0  Length: 0  Speed: 0s  Mode: " + snake.DefaultMode + "  Time: 00:00  Lives: 2"
+		if got := s.String(); got != want {
+			t.Errorf("got status %q, want %q", got, want)
+		}
+		s.Lives = 0
+		if got := s.String(); !strings.HasSuffix(got, "Lives: 0") {
+			t.Errorf("got status %q, want the lives over displayed", got)
+		}
+	})
+}
+
+func receiveLife(t testing.TB, g *snake.Game) snake.Life {
+	t.Helper()
+	return <-g.ReceiveLives()
+}
+
+func assertLife(t testing.TB, got, want snake.Life) {
+	t.Helper()
+	if got != want {
+		t.Errorf("got life %+v, want %+v", got, want)
+	}
+}
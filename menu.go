@@ -0,0 +1,65 @@
+package snake
+
+import "github.com/gdamore/tcell/v2"
+
+// Menu is a titled list of selectable items, optionally preceded by
+// lines of descriptive text.
+type Menu struct {
+	Title    string
+	Text     []string
+	Items    []string
+	Selected int
+}
+
+// Next selects the next menu item, wrapping to the first one.
+func (m *Menu) Next() {
+	if len(m.Items) == 0 {
+		return
+	}
+	m.Selected = (m.Selected + 1) % len(m.Items)
+}
+
+// Previous selects the previous menu item, wrapping to the last one.
+func (m *Menu) Previous() {
+	if len(m.Items) == 0 {
+		return
+	}
+	m.Selected = (m.Selected - 1 + len(m.Items)) % len(m.Items)
+}
+
+// Item returns the selected menu item, or an empty string if the menu has no items.
+func (m *Menu) Item() string {
+	if m.Selected < 0 || m.Selected >= len(m.Items) {
+		return ""
+	}
+	return m.Items[m.Selected]
+}
+
+// DisplayMenu displays m in a panel centered on the screen, over the last
+// board if any, highlighting the selected item.
+func (v *View) DisplayMenu(m Menu) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.menu, v.dialog = &m, nil
+	v.draw()
+}
+
+// panel returns the menu panel: the text lines, an empty line,
+// then the centered items.
+func (m *Menu) panel() panel {
+	p := panel{title: m.Title}
+	for _, t := range m.Text {
+		p.lines = append(p.lines, panelLine{t, tcell.StyleDefault, false})
+	}
+	if len(m.Text) > 0 {
+		p.lines = append(p.lines, panelLine{"", tcell.StyleDefault, false})
+	}
+	for i, item := range m.Items {
+		style := tcell.StyleDefault
+		if i == m.Selected {
+			style = style.Reverse(true)
+		}
+		p.lines = append(p.lines, panelLine{item, style, true})
+	}
+	return p
+}
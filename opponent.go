@@ -0,0 +1,317 @@
+package snake
+
+import (
+	"math/rand"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	ErrUnknownStrategy  = SnakeErr("snake: unknown opponent strategy")
+	ErrInvalidOpponents = SnakeErr("snake: invalid opponents")
+)
+
+// opponentSpacing is the number of rows between the start rows of the snakes.
+const opponentSpacing = 3
+
+// OpponentColors are the colors of the opponent snakes: the i-th opponent
+// is drawn with OpponentColors[i%len(OpponentColors)].
+var OpponentColors = []tcell.Color{
+	tcell.ColorLightSkyBlue,
+	tcell.ColorViolet,
+	tcell.ColorPaleGreen,
+	tcell.ColorSandyBrown,
+	tcell.ColorHotPink,
+}
+
+// Difficulty is how often an opponent makes a random move
+// instead of the move chosen by its strategy.
+type Difficulty string
+
+const (
+	Easy   Difficulty = "easy"
+	Normal Difficulty = "normal"
+	Hard   Difficulty = "hard"
+)
+
+// mistakes returns the probability of a random move, or a negative
+// probability if d is not a difficulty. The zero Difficulty is Normal.
+func (d Difficulty) mistakes() float64 {
+	switch d {
+	case Easy:
+		return 0.25
+	case Normal, "":
+		return 0.1
+	case Hard:
+		return 0
+	}
+	return -1
+}
+
+// Arena is the board as seen by an opponent strategy on a tick: the opponent
+// Snake, with the head first, and its Face direction, the other snakes on the
+// board, the Food, and the Obstacles, which are the cells of the portals, of
+// the blocks and of the hazards. The cells outside the Bounds are walls,
+// unless the Bounds are zero.
+type Arena struct {
+	Width     int
+	Height    int
+	Snake     []Coordinate
+	Face      Direction
+	Rivals    [][]Coordinate
+	Food      Coordinate
+	Obstacles []Coordinate
+	Bounds    Bounds
+}
+
+// Free reports whether c is inside the board and the walls and not on a
+// snake, except the opponent tail which moves away, nor on an obstacle.
+func (a Arena) Free(c Coordinate) bool {
+	if c.X < 0 || c.X >= a.Width || c.Y < 0 || c.Y >= a.Height {
+		return false
+	}
+	if a.Bounds != (Bounds{}) && !a.Bounds.Contains(c) {
+		return false
+	}
+	if len(a.Snake) > 0 && contains(a.Snake[:len(a.Snake)-1], c) || contains(a.Obstacles, c) {
+		return false
+	}
+	for _, r := range a.Rivals {
+		if contains(r, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Moves returns the directions, in the Up, Down, Left, Right order, which
+// move the opponent head on a free cell, turning back excluded.
+func (a Arena) Moves() []Direction {
+	if len(a.Snake) == 0 {
+		return nil
+	}
+	var moves []Direction
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		if d != opposite(a.Face) && a.Free(step(a.Snake[0], d)) {
+			moves = append(moves, d)
+		}
+	}
+	return moves
+}
+
+// space returns the number of free cells reachable from c, up to limit.
+func (a Arena) space(c Coordinate, limit int) int {
+	if !a.Free(c) {
+		return 0
+	}
+	seen := []Coordinate{c}
+	for i := 0; i < len(seen) && len(seen) < limit; i++ {
+		for _, d := range []Direction{Up, Down, Left, Right} {
+			next := step(seen[i], d)
+			if next != a.Snake[0] && a.Free(next) && !contains(seen, next) {
+				seen = append(seen, next)
+			}
+		}
+	}
+	return len(seen)
+}
+
+// Strategy drives an opponent snake.
+type Strategy interface {
+	// Move should return the direction the opponent moves towards on the tick.
+	Move(a Arena) Direction
+}
+
+// StrategyFunc is a function used as a Strategy.
+type StrategyFunc func(a Arena) Direction
+
+func (f StrategyFunc) Move(a Arena) Direction {
+	return f(a)
+}
+
+// Strategies are the opponent strategies by name, whose functions return
+// a strategy drawing its random moves from r. Custom strategies can be
+// added before the opponents are set.
+var Strategies = map[string]func(r *rand.Rand) Strategy{
+	"random":   RandomStrategy,
+	"greedy":   GreedyStrategy,
+	"cautious": CautiousStrategy,
+}
+
+// RandomStrategy returns a strategy which moves on a free cell at random
+// with r, going straight if there is none.
+func RandomStrategy(r *rand.Rand) Strategy {
+	return StrategyFunc(func(a Arena) Direction {
+		moves := a.Moves()
+		if len(moves) == 0 {
+			return a.Face
+		}
+		return moves[r.Intn(len(moves))]
+	})
+}
+
+// GreedyStrategy returns a strategy which moves on the free cell closest
+// to the food, going straight if there is none.
+func GreedyStrategy(r *rand.Rand) Strategy {
+	return StrategyFunc(func(a Arena) Direction {
+		return closestToFood(a, a.Moves())
+	})
+}
+
+// CautiousStrategy returns a strategy which moves on the free cell closest
+// to the food among the ones leading to enough room for the whole snake,
+// or on the one leading to the most room if there are none.
+func CautiousStrategy(r *rand.Rand) Strategy {
+	return StrategyFunc(func(a Arena) Direction {
+		var roomy []Direction
+		best, most := a.Face, -1
+		for _, d := range a.Moves() {
+			room := a.space(step(a.Snake[0], d), len(a.Snake))
+			if room >= len(a.Snake) {
+				roomy = append(roomy, d)
+			}
+			if room > most {
+				best, most = d, room
+			}
+		}
+		if len(roomy) == 0 {
+			return best
+		}
+		return closestToFood(a, roomy)
+	})
+}
+
+// closestToFood returns the first of moves which moves the opponent head
+// closest to the food, or the opponent face direction if there are no moves.
+func closestToFood(a Arena, moves []Direction) Direction {
+	best, distance := a.Face, -1
+	for _, d := range moves {
+		if m := manhattan(step(a.Snake[0], d), a.Food); distance < 0 || m < distance {
+			best, distance = d, m
+		}
+	}
+	return best
+}
+
+// Opponent is a snake driven by the strategy named Strategy among Strategies,
+// which makes random moves as often as its Difficulty. Its random moves are
+// drawn from a generator seeded with Seed, so the same opponents play the
+// same moves on the same boards.
+type Opponent struct {
+	Name       string     `json:"name"`
+	Strategy   string     `json:"strategy"`
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	Seed       int64      `json:"seed,omitempty"`
+}
+
+// validate returns ErrUnknownStrategy if the opponent strategy is not one
+// of Strategies, and ErrInvalidOpponents if its difficulty is unknown.
+func (o Opponent) validate() error {
+	if _, ok := Strategies[o.Strategy]; !ok {
+		return ErrUnknownStrategy
+	}
+	if o.Difficulty.mistakes() < 0 {
+		return ErrInvalidOpponents
+	}
+	return nil
+}
+
+// OpponentState is the state of an opponent in a game: its snake, with the
+// head first, its face direction, whether it is alive and its score.
+// The snake of a dead opponent is removed from the board. Ate is set on
+// the opponents which ate the food on the last tick.
+type OpponentState struct {
+	Opponent
+	Snake []Coordinate `json:"snake,omitempty"`
+	Face  Direction    `json:"face,omitempty"`
+	Alive bool         `json:"alive"`
+	Score int          `json:"score"`
+	Ate   bool         `json:"-"`
+}
+
+// rival is an opponent playing a game.
+type rival struct {
+	Opponent
+	snake     *Snake
+	strategy  Strategy
+	rng       *rand.Rand
+	direction Direction
+	alive     bool
+	score     int
+	ate       bool
+}
+
+// newRival returns the rival playing o on the board of the snake player,
+// sharing its portals and its bounds.
+// The rival is not alive until it is placed on the board.
+func newRival(o Opponent, player *Snake) *rival {
+	rng := rand.New(rand.NewSource(o.Seed))
+	s := NewSnake(player.width, player.height)
+	s.portals, s.bounds = player.portals, player.bounds
+	return &rival{Opponent: o, snake: s, strategy: Strategies[o.Strategy](rng), rng: rng, direction: Left}
+}
+
+// choose sets the direction the rival moves towards on the tick, chosen by
+// its strategy or, as often as its difficulty, at random among the free cells.
+func (r *rival) choose(a Arena) {
+	d := r.strategy.Move(a)
+	if moves := a.Moves(); len(moves) > 0 && r.rng.Float64() < r.Difficulty.mistakes() {
+		d = moves[r.rng.Intn(len(moves))]
+	}
+	if r.snake.IsValidMove(d) {
+		r.direction = d
+	}
+}
+
+// state returns the rival state.
+func (r *rival) state() OpponentState {
+	s := OpponentState{Opponent: r.Opponent, Alive: r.alive, Score: r.score, Ate: r.ate}
+	if r.alive {
+		s.Snake, s.Face = append([]Coordinate{}, r.snake.GetCoordinates()...), r.snake.Face()
+	}
+	return s
+}
+
+// opponentStart returns the head cell on which the i-th opponent starts,
+// on the rows alternately above and below the start row of the snake s.
+func opponentStart(s *Snake, i int) Coordinate {
+	head := s.startCoordinates()[0]
+	offset := (i/2 + 1) * opponentSpacing
+	if i%2 == 1 {
+		offset = -offset
+	}
+	return Coordinate{head.X, head.Y - offset}
+}
+
+// rivalCells returns the cells of the snakes of the alive rivals.
+func rivalCells(rivals []*rival) []Coordinate {
+	var cells []Coordinate
+	for _, r := range rivals {
+		if r.alive {
+			cells = append(cells, r.snake.GetCoordinates()...)
+		}
+	}
+	return cells
+}
+
+// lastAlive reports whether there are rivals and all of them are dead.
+func lastAlive(rivals []*rival) bool {
+	for _, r := range rivals {
+		if r.alive {
+			return false
+		}
+	}
+	return len(rivals) > 0
+}
+
+// rivalStates returns the states of the rivals.
+func rivalStates(rivals []*rival) []OpponentState {
+	if rivals == nil {
+		return nil
+	}
+	states := make([]OpponentState, len(rivals))
+	for i, r := range rivals {
+		states[i] = r.state()
+	}
+	return states
+}
@@ -0,0 +1,204 @@
+package snake_test
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestOpponents(t *testing.T) {
+	// registerStrategy adds the strategy named name, which always moves
+	// towards d, to the strategies until the test ends.
+	registerStrategy := func(t *testing.T, name string, d snake.Direction) {
+		t.Helper()
+		snake.Strategies[name] = func(r *rand.Rand) snake.Strategy {
+			return snake.StrategyFunc(func(a snake.Arena) snake.Direction { return d })
+		}
+		t.Cleanup(func() { delete(snake.Strategies, name) })
+	}
+	// startGame starts a 20x10 game of s with the opponents, skipping the
+	// first opponents, snake and food coordinates sent. The opponents start
+	// on the rows 2 and 8, with their head on the column 12 like s.
+	startGame := func(t *testing.T, s *snake.Snake, opponents []snake.Opponent, food ...snake.Coordinate) (*snake.Game, *snake.FakeClock) {
+		t.Helper()
+		fs := &snake.FoodStub{}
+		values := []snake.FoodStubValue{}
+		for _, c := range food {
+			values = append(values, snake.FoodStubValue{c, nil})
+		}
+		fs.Seed(values)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
+		t.Cleanup(g.Quit)
+		snake.AssertNoError(t, g.SetOpponents(opponents))
+		g.Start(time.Microsecond)
+		receiveOpponents(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		return g, clock
+	}
+
+	t.Run("strategies should move on the free cells", func(t *testing.T) {
+		arena := snake.Arena{Width: 10, Height: 10, Snake: []snake.Coordinate{{5, 5}, {6, 5}, {7, 5}}, Face: snake.Left}
+
+		greedy := arena
+		greedy.Food, greedy.Obstacles = snake.Coordinate{0, 0}, []snake.Coordinate{{5, 4}}
+		if got := snake.GreedyStrategy(nil).Move(greedy); got != snake.Left {
+			t.Errorf("got greedy move %v, want %v", got, snake.Left)
+		}
+
+		cautious := arena
+		cautious.Food, cautious.Rivals = snake.Coordinate{5, 0}, [][]snake.Coordinate{{{4, 4}, {5, 3}, {6, 4}}}
+		if got := snake.GreedyStrategy(nil).Move(cautious); got != snake.Up {
+			t.Errorf("got greedy move %v, want %v", got, snake.Up)
+		}
+		if got := snake.CautiousStrategy(nil).Move(cautious); got != snake.Down {
+			t.Errorf("got cautious move %v avoiding the dead end, want %v", got, snake.Down)
+		}
+
+		random := arena
+		random.Obstacles = []snake.Coordinate{{5, 4}, {4, 5}}
+		if got := snake.RandomStrategy(rand.New(rand.NewSource(1))).Move(random); got != snake.Down {
+			t.Errorf("got random move %v, want the only free move %v", got, snake.Down)
+		}
+	})
+
+	t.Run("should not set unknown strategies and difficulties", func(t *testing.T) {
+		g := snake.NewGame(snake.NewSnake(20, 10), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer g.Quit()
+		snake.AssertError(t, g.SetOpponents([]snake.Opponent{{Name: "Bot", Strategy: "unknown"}}), snake.ErrUnknownStrategy)
+		err := g.SetOpponents([]snake.Opponent{{Name: "Bot", Strategy: "greedy", Difficulty: "impossible"}})
+		if !errors.Is(err, snake.ErrInvalidOpponents) {
+			t.Errorf("got error %v, want %v", err, snake.ErrInvalidOpponents)
+		}
+	})
+
+	t.Run("opponents should die hitting the board sides", func(t *testing.T) {
+		registerStrategy(t, "up", snake.Up)
+		g, clock := startGame(t, snake.NewSnake(20, 10), []snake.Opponent{{Name: "Bot", Strategy: "up", Difficulty: snake.Hard}}, snake.Coordinate{0, 0})
+
+		for _, alive := range []bool{true, true, false} {
+			clock.Advance(time.Microsecond)
+			o := receiveOpponents(t, g)
+			_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+			if o[0].Alive != alive {
+				t.Fatalf("got opponent %+v alive %v, want %v", o[0], o[0].Alive, alive)
+			}
+		}
+	})
+
+	t.Run("opponents should eat the food without scoring for the snake", func(t *testing.T) {
+		registerStrategy(t, "left", snake.Left)
+		g, clock := startGame(t, snake.NewSnake(20, 10), []snake.Opponent{{Name: "Bot", Strategy: "left", Difficulty: snake.Hard}}, snake.Coordinate{11, 2}, snake.Coordinate{0, 0})
+
+		clock.Advance(time.Microsecond)
+		o := receiveOpponents(t, g)
+		if !o[0].Ate || o[0].Score != 1 || len(o[0].Snake) != 4 {
+			t.Errorf("got opponent %+v, want it to eat the food and grow", o[0])
+		}
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, _, f := snake.WaitAndReceiveGameChannels(t, g)
+		if f == nil || *f != (snake.Coordinate{0, 0}) {
+			t.Errorf("got food %v, want a new food on %v", f, snake.Coordinate{0, 0})
+		}
+		if s := g.Save(); s.Score != 0 || s.Eaten != 0 {
+			t.Errorf("got score %d and %d eaten, want no score for the snake", s.Score, s.Eaten)
+		}
+	})
+
+	t.Run("opponents should die hitting the snake body", func(t *testing.T) {
+		registerStrategy(t, "down", snake.Down)
+		g, clock := startGame(t, snake.NewSnakeOfLength(20, 10, 6), []snake.Opponent{{Name: "Bot", Strategy: "down", Difficulty: snake.Hard}}, snake.Coordinate{0, 0})
+
+		for _, alive := range []bool{true, true, false} {
+			clock.Advance(time.Microsecond)
+			o := receiveOpponents(t, g)
+			_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+			if o[0].Alive != alive {
+				t.Fatalf("got opponent %+v alive %v, want %v", o[0], o[0].Alive, alive)
+			}
+		}
+	})
+
+	t.Run("snakes colliding head on should both die whatever their order", func(t *testing.T) {
+		registerStrategy(t, "down", snake.Down)
+		g, clock := startGame(t, snake.NewSnake(20, 10), []snake.Opponent{{Name: "Bot", Strategy: "down", Difficulty: snake.Hard}}, snake.Coordinate{0, 0})
+
+		g.SendMove(snake.Up)
+		clock.Advance(time.Microsecond)
+		receiveOpponents(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		o := receiveOpponents(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, false)
+		if o[0].Alive {
+			t.Errorf("got opponent %+v alive, want it dead", o[0])
+		}
+	})
+
+	t.Run("should save and restore the opponents", func(t *testing.T) {
+		opponents := []snake.Opponent{
+			{Name: "Bot 1", Strategy: "greedy", Difficulty: snake.Easy, Seed: 1},
+			{Name: "Bot 2", Strategy: "cautious", Difficulty: snake.Hard, Seed: 2},
+		}
+		g, clock := startGame(t, snake.NewSnake(20, 10), opponents, snake.Coordinate{0, 0})
+		clock.Advance(time.Microsecond)
+		receiveOpponents(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+
+		s := g.Save()
+		if len(s.Opponents) != 2 || s.Opponents[1].Opponent != opponents[1] || !s.Opponents[1].Alive {
+			t.Fatalf("got opponents %+v, want %+v alive", s.Opponents, opponents)
+		}
+		restored := snake.NewGame(snake.NewSnake(20, 10), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer restored.Quit()
+		snake.AssertNoError(t, restored.Restore(s))
+		if got := restored.Save().Opponents; !reflect.DeepEqual(got, s.Opponents) {
+			t.Errorf("got opponents %+v after restoring, want %+v", got, s.Opponents)
+		}
+
+		s.Opponents[0].Snake = s.Snake
+		if err := s.Validate(); !errors.Is(err, snake.ErrInvalidSave) {
+			t.Errorf("got error %v validating an opponent on the snake, want %v", err, snake.ErrInvalidSave)
+		}
+	})
+
+	t.Run("controller should set the opponents on the view", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		snakeCoordinates := []snake.Coordinate{{0, 1}}
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		game.SendSnakeCoordinates(t, snakeCoordinates)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		view.GetStatus(t)
+
+		opponents := []snake.OpponentState{{Opponent: snake.Opponent{Name: "Bot", Strategy: "greedy"}, Snake: []snake.Coordinate{{5, 5}, {6, 5}}, Alive: true, Score: 1, Ate: true}}
+		game.SendOpponents(t, opponents)
+		got := <-view.OpponentsC
+		if !reflect.DeepEqual(got, opponents) {
+			t.Errorf("got opponents %+v, want %+v", got, opponents)
+		}
+		game.SendFoodCoordinate(t, snake.Coordinate{7, 7})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		assertStatus(t, view.GetStatus(t), 0, len(snakeCoordinates))
+	})
+}
+
+func receiveOpponents(t testing.TB, g *snake.Game) []snake.OpponentState {
+	t.Helper()
+	return <-g.ReceiveOpponents()
+}
@@ -0,0 +1,147 @@
+package snake
+
+import (
+	"math/rand"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const ErrInvalidPortals = SnakeErr("snake: invalid portals")
+
+// maxRandomDraws is how many random placements RandomPortals
+// and RandomEntities draw for each item before giving up.
+const maxRandomDraws = 100
+
+// PortalRune is the rune drawn on the portal cells.
+const PortalRune = '◎'
+
+// PortalColors are the colors of the portal pairs: the i-th portal
+// is drawn with PortalColors[i%len(PortalColors)].
+var PortalColors = []tcell.Color{
+	tcell.ColorAqua,
+	tcell.ColorFuchsia,
+	tcell.ColorLime,
+	tcell.ColorOrange,
+	tcell.ColorBlue,
+}
+
+// Portal is a pair of board cells: when the snake head moves on one of them,
+// it emerges from the cell next to the other one, keeping its direction.
+// The body threads through the portal following the head, so the snake
+// never covers the portal cells.
+type Portal struct {
+	A Coordinate `json:"a"`
+	B Coordinate `json:"b"`
+}
+
+// partner returns the cell paired with c by one of portals,
+// and false if c is not a portal cell.
+func partner(portals []Portal, c Coordinate) (Coordinate, bool) {
+	for _, p := range portals {
+		switch c {
+		case p.A:
+			return p.B, true
+		case p.B:
+			return p.A, true
+		}
+	}
+	return Coordinate{}, false
+}
+
+// step returns the coordinate next to c towards d.
+func step(c Coordinate, d Direction) Coordinate {
+	switch d {
+	case Up:
+		c.Y--
+	case Down:
+		c.Y++
+	case Left:
+		c.X--
+	case Right:
+		c.X++
+	}
+	return c
+}
+
+// stepThrough returns the coordinate reached moving from c towards d,
+// emerging from the partner portal cell if the move enters a portal.
+func stepThrough(portals []Portal, c Coordinate, d Direction) Coordinate {
+	next := step(c, d)
+	if exit, ok := partner(portals, next); ok {
+		return step(exit, d)
+	}
+	return next
+}
+
+// link returns the Direction to move from the from coordinate to reach
+// the to coordinate, either because they are adjacent or because the move
+// goes through one of portals. It returns 0 if to can not be reached in a move.
+func link(portals []Portal, from, to Coordinate) Direction {
+	if d := direction(from, to); d != 0 {
+		return d
+	}
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		if _, ok := partner(portals, step(from, d)); ok && stepThrough(portals, from, d) == to {
+			return d
+		}
+	}
+	return 0
+}
+
+// portalCells returns the cells of portals.
+func portalCells(portals []Portal) []Coordinate {
+	cells := make([]Coordinate, 0, 2*len(portals))
+	for _, p := range portals {
+		cells = append(cells, p.A, p.B)
+	}
+	return cells
+}
+
+// validatePortals returns ErrInvalidPortals if a portal cell is outside the
+// width x height board, is used twice, is adjacent to a cell of another
+// portal, or is one of the occupied coordinates.
+func validatePortals(portals []Portal, width, height int, occupied []Coordinate) error {
+	cells := portalCells(portals)
+	for i, c := range cells {
+		if c.X < 0 || c.X >= width || c.Y < 0 || c.Y >= height ||
+			contains(cells[:i], c) || contains(occupied, c) {
+			return ErrInvalidPortals
+		}
+		for j, other := range cells {
+			if j/2 != i/2 && direction(c, other) != 0 {
+				return ErrInvalidPortals
+			}
+		}
+	}
+	return nil
+}
+
+// RandomPortals returns n portals placed at random with r on a width x height
+// board, off the occupied coordinates. The portal cells are not on the board
+// sides, so the snake always emerges inside the board. It returns
+// ErrInvalidPortals if the portals do not fit the board.
+func RandomPortals(n, width, height int, occupied []Coordinate, r *rand.Rand) ([]Portal, error) {
+	if width < 3 || height < 3 {
+		if n == 0 {
+			return nil, nil
+		}
+		return nil, ErrInvalidPortals
+	}
+	portals := make([]Portal, 0, n)
+	for draws := 0; len(portals) < n; draws++ {
+		if draws == n*maxRandomDraws {
+			return nil, ErrInvalidPortals
+		}
+		p := Portal{
+			Coordinate{1 + r.Intn(width-2), 1 + r.Intn(height-2)},
+			Coordinate{1 + r.Intn(width-2), 1 + r.Intn(height-2)},
+		}
+		if direction(p.A, p.B) != 0 {
+			continue
+		}
+		if validatePortals(append(portals, p), width, height, occupied) == nil {
+			portals = append(portals, p)
+		}
+	}
+	return portals, nil
+}
@@ -0,0 +1,124 @@
+package snake_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestPortal(t *testing.T) {
+	portal := snake.Portal{A: snake.Coordinate{X: 35, Y: 30}, B: snake.Coordinate{X: 10, Y: 10}}
+
+	t.Run("should move the snake through the portal", func(t *testing.T) {
+		s := snake.NewSnake(60, 60)
+		err := s.SetPortals([]snake.Portal{portal})
+		snake.AssertNoError(t, err)
+
+		err = s.Move(snake.Left)
+		snake.AssertNoError(t, err)
+		snake.AssertCoordinates(t, s.GetCoordinates(), []snake.Coordinate{{9, 10}, {36, 30}, {37, 30}})
+		s.Move(snake.Left)
+		s.Move(snake.Left)
+		snake.AssertCoordinates(t, s.GetCoordinates(), []snake.Coordinate{{7, 10}, {8, 10}, {9, 10}})
+		snake.AssertDirection(t, s.Face(), snake.Left)
+	})
+
+	t.Run("should hit the body through the portal", func(t *testing.T) {
+		s := snake.NewSnakeOfLength(60, 60, 5)
+		err := s.SetPortals([]snake.Portal{{A: snake.Coordinate{X: 35, Y: 30}, B: snake.Coordinate{X: 38, Y: 29}}})
+		snake.AssertNoError(t, err)
+
+		err = s.Move(snake.Up)
+		snake.AssertNoError(t, err)
+		err = s.Move(snake.Right)
+		snake.AssertNoError(t, err)
+		err = s.Move(snake.Right)
+		snake.AssertError(t, err, snake.ErrHeadHitBody)
+	})
+
+	t.Run("should not set invalid portals", func(t *testing.T) {
+		cases := map[string][]snake.Portal{
+			"out of board":  {{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 60, Y: 1}}},
+			"under snake":   {{A: snake.Coordinate{X: 36, Y: 30}, B: snake.Coordinate{X: 1, Y: 1}}},
+			"same cell":     {{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 1, Y: 1}}},
+			"shared cell":   {portal, {A: portal.B, B: snake.Coordinate{X: 1, Y: 1}}},
+			"adjacent cell": {portal, {A: snake.Coordinate{X: 10, Y: 11}, B: snake.Coordinate{X: 1, Y: 1}}},
+		}
+		for name, portals := range cases {
+			s := snake.NewSnake(60, 60)
+			err := s.SetPortals(portals)
+			if err != snake.ErrInvalidPortals {
+				t.Errorf("got error %v setting portals %s, want %v", err, name, snake.ErrInvalidPortals)
+			}
+			if len(s.Portals()) != 0 {
+				t.Errorf("got portals %v after setting portals %s, want none", s.Portals(), name)
+			}
+		}
+	})
+
+	t.Run("should restore a snake threading through the portal", func(t *testing.T) {
+		s := snake.NewSnake(60, 60)
+		s.SetPortals([]snake.Portal{portal})
+		coordinates := []snake.Coordinate{{9, 10}, {36, 30}, {37, 30}}
+
+		err := s.Restore(coordinates, snake.Left, &snake.Coordinate{X: 38, Y: 30})
+		snake.AssertNoError(t, err)
+		snake.AssertCoordinates(t, s.GetCoordinates(), coordinates)
+
+		err = s.Restore([]snake.Coordinate{{10, 10}, {11, 10}, {12, 10}}, snake.Left, nil)
+		snake.AssertError(t, err, snake.ErrInvalidSnake)
+	})
+
+	t.Run("should drop the portals out of the resized board", func(t *testing.T) {
+		s := snake.NewSnake(60, 60)
+		inside := snake.Portal{A: snake.Coordinate{X: 1, Y: 1}, B: snake.Coordinate{X: 3, Y: 3}}
+		s.SetPortals([]snake.Portal{inside, portal})
+
+		s.Resize(20, 20)
+
+		got := s.Portals()
+		if len(got) != 1 || got[0] != inside {
+			t.Errorf("got portals %v, want %v", got, []snake.Portal{inside})
+		}
+	})
+
+	t.Run("should place random portals off the board sides", func(t *testing.T) {
+		s := snake.NewSnake(20, 20)
+		portals, err := snake.RandomPortals(3, 20, 20, s.GetCoordinates(), rand.New(rand.NewSource(42)))
+		snake.AssertNoError(t, err)
+
+		if len(portals) != 3 {
+			t.Fatalf("got %d portals, want 3", len(portals))
+		}
+		for _, p := range portals {
+			for _, c := range []snake.Coordinate{p.A, p.B} {
+				if c.X < 1 || c.X > 18 || c.Y < 1 || c.Y > 18 {
+					t.Errorf("got portal cell %v on the board side", c)
+				}
+			}
+		}
+		snake.AssertNoError(t, s.SetPortals(portals))
+	})
+
+	t.Run("should not place random portals which do not fit", func(t *testing.T) {
+		_, err := snake.RandomPortals(10, 4, 4, nil, rand.New(rand.NewSource(42)))
+		snake.AssertError(t, err, snake.ErrInvalidPortals)
+	})
+
+	t.Run("should not generate food on the portals", func(t *testing.T) {
+		s := snake.NewSnake(6, 3)
+		s.SetPortals([]snake.Portal{{A: snake.Coordinate{X: 0, Y: 0}, B: snake.Coordinate{X: 5, Y: 2}}})
+		food := snake.NewSeededFood(6, 3, 42)
+		e, err := snake.NewEngine(s, food)
+		snake.AssertNoError(t, err)
+
+		for i := 0; i < 20; i++ {
+			got := e.State().Food
+			if got == (snake.Coordinate{X: 0, Y: 0}) || got == (snake.Coordinate{X: 5, Y: 2}) {
+				t.Fatalf("got food %v on a portal", got)
+			}
+			e.Reset()
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package snake
+
+import "github.com/gdamore/tcell/v2"
+
+const UpperHalfBlockRune = '▀'
+const LowerHalfBlockRune = '▄'
+
+// Cell is the content of a board cell.
+//
+// Rune and Style are used by renderers which draw a board cell
+// with a character, Color is used by renderers which draw a board cell
+// as a solid block.
+type Cell struct {
+	Rune  rune
+	Style tcell.Style
+	Color tcell.Color
+}
+
+// Renderer interface defines how the board cells are drawn on a screen.
+type Renderer interface {
+	// BoardSize should return the board width and height which fit
+	// in a screen area of cols columns and rows rows.
+	BoardSize(cols, rows int) (width, height int)
+	// ScreenSize should return the screen columns and rows covered
+	// by a board of width x height cells.
+	ScreenSize(width, height int) (cols, rows int)
+	// ScreenCell should return the screen cell, relative to the board
+	// top left corner, on which the board coordinate c is drawn.
+	ScreenCell(c Coordinate) (col, row int)
+	// Draw should draw the board cells, indexed as cells[y][x],
+	// placing the board top left corner on the x, y screen cell.
+	// Empty cells are zero values and should not be drawn.
+	Draw(screen tcell.Screen, x, y int, cells [][]Cell)
+}
+
+// TextRenderer draws each board cell on a screen cell using the cell rune.
+type TextRenderer struct{}
+
+// BoardSize returns cols and rows, since each board cell fills a screen cell.
+func (TextRenderer) BoardSize(cols, rows int) (int, int) {
+	return cols, rows
+}
+
+// ScreenSize returns width and height, since each board cell fills a screen cell.
+func (TextRenderer) ScreenSize(width, height int) (int, int) {
+	return width, height
+}
+
+// ScreenCell returns the c coordinate unchanged.
+func (TextRenderer) ScreenCell(c Coordinate) (int, int) {
+	return c.X, c.Y
+}
+
+// Draw draws each non empty cell rune with the cell style.
+func (TextRenderer) Draw(screen tcell.Screen, x, y int, cells [][]Cell) {
+	for row := range cells {
+		for col, c := range cells[row] {
+			if c == (Cell{}) {
+				continue
+			}
+			screen.SetContent(x+col, y+row, c.Rune, nil, c.Style)
+		}
+	}
+}
+
+// HalfBlockRenderer packs two board rows in a screen row, drawing each
+// board cell as a solid half block of the cell color. Since terminal cells
+// are about twice as tall as wide, the board cells look square.
+type HalfBlockRenderer struct{}
+
+// BoardSize returns cols and twice the rows, since each screen cell
+// holds two board cells stacked vertically.
+func (HalfBlockRenderer) BoardSize(cols, rows int) (int, int) {
+	return cols, rows * 2
+}
+
+// ScreenSize returns width and half the height rounded up.
+func (HalfBlockRenderer) ScreenSize(width, height int) (int, int) {
+	return width, (height + 1) / 2
+}
+
+// ScreenCell returns the screen cell which holds the c coordinate,
+// on the same column and on half the row.
+func (HalfBlockRenderer) ScreenCell(c Coordinate) (int, int) {
+	return c.X, c.Y / 2
+}
+
+// Draw draws the upper board cell with the foreground color of an upper
+// half block and the lower board cell with its background color.
+// If only one of the two board cells is not empty it draws
+// the respective half block leaving the default background.
+func (HalfBlockRenderer) Draw(screen tcell.Screen, x, y int, cells [][]Cell) {
+	for row := 0; row < len(cells); row += 2 {
+		for col, top := range cells[row] {
+			var bottom Cell
+			if row+1 < len(cells) {
+				bottom = cells[row+1][col]
+			}
+			r, style := halfBlock(top, bottom)
+			if r == 0 {
+				continue
+			}
+			screen.SetContent(x+col, y+row/2, r, nil, style)
+		}
+	}
+}
+
+func halfBlock(top, bottom Cell) (rune, tcell.Style) {
+	topEmpty, bottomEmpty := top == (Cell{}), bottom == (Cell{})
+	switch {
+	case topEmpty && bottomEmpty:
+		return 0, tcell.StyleDefault
+	case bottomEmpty:
+		return UpperHalfBlockRune, tcell.StyleDefault.Foreground(top.Color)
+	case topEmpty:
+		return LowerHalfBlockRune, tcell.StyleDefault.Foreground(bottom.Color)
+	}
+	return UpperHalfBlockRune, tcell.StyleDefault.Foreground(top.Color).Background(bottom.Color)
+}
+
+// newCells returns an empty width x height board cells grid.
+func newCells(width, height int) [][]Cell {
+	cells := make([][]Cell, height)
+	for y := range cells {
+		cells[y] = make([]Cell, width)
+	}
+	return cells
+}
+
+// setCell sets the cell at coordinate c, ignoring coordinates out of the grid.
+func setCell(cells [][]Cell, c Coordinate, cell Cell) {
+	if c.Y < 0 || c.Y >= len(cells) || c.X < 0 || c.X >= len(cells[c.Y]) {
+		return
+	}
+	cells[c.Y][c.X] = cell
+}
@@ -0,0 +1,91 @@
+package snake_test
+
+import (
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderer(t *testing.T) {
+	red := snake.Cell{'r', tcell.StyleDefault.Foreground(tcell.ColorRed), tcell.ColorRed}
+	blue := snake.Cell{'b', tcell.StyleDefault.Foreground(tcell.ColorBlue), tcell.ColorBlue}
+	cells := [][]snake.Cell{
+		{red, {}, red},
+		{blue, blue, {}},
+		{{}, red, {}},
+	}
+
+	t.Run("text renderer should fit a board cell in a screen cell", func(t *testing.T) {
+		width, height := snake.TextRenderer{}.BoardSize(10, 20)
+		assertBoardSize(t, width, height, 10, 20)
+	})
+
+	t.Run("text renderer should draw cell runes", func(t *testing.T) {
+		screen := initScreen(t, 10, 10)
+
+		snake.TextRenderer{}.Draw(screen, 1, 2, cells)
+		screen.Show()
+
+		for y, row := range cells {
+			for x, c := range row {
+				r, _, s, _ := screen.GetContent(x+1, y+2)
+				if c == (snake.Cell{}) {
+					assertCellRune(t, x+1, y+2, r, ' ')
+					continue
+				}
+				assertCellRune(t, x+1, y+2, r, c.Rune)
+				fg, _, _ := s.Decompose()
+				assertForegroundColor(t, x+1, y+2, fg, c.Color)
+			}
+		}
+	})
+
+	t.Run("half block renderer should fit two board rows in a screen row", func(t *testing.T) {
+		width, height := snake.HalfBlockRenderer{}.BoardSize(10, 20)
+		assertBoardSize(t, width, height, 10, 40)
+	})
+
+	t.Run("half block renderer should pack two board rows in a screen row", func(t *testing.T) {
+		screen := initScreen(t, 10, 10)
+
+		snake.HalfBlockRenderer{}.Draw(screen, 0, 0, cells)
+		screen.Show()
+
+		cases := []struct {
+			x, y   int
+			rune   rune
+			fg, bg tcell.Color
+		}{
+			{0, 0, snake.UpperHalfBlockRune, tcell.ColorRed, tcell.ColorBlue},
+			{1, 0, snake.LowerHalfBlockRune, tcell.ColorBlue, tcell.ColorDefault},
+			{2, 0, snake.UpperHalfBlockRune, tcell.ColorRed, tcell.ColorDefault},
+			{0, 1, ' ', tcell.ColorDefault, tcell.ColorDefault},
+			{1, 1, snake.UpperHalfBlockRune, tcell.ColorRed, tcell.ColorDefault},
+		}
+		for _, c := range cases {
+			r, _, s, _ := screen.GetContent(c.x, c.y)
+			assertCellRune(t, c.x, c.y, r, c.rune)
+			fg, bg, _ := s.Decompose()
+			assertForegroundColor(t, c.x, c.y, fg, c.fg)
+			assertBackgroundColor(t, c.x, c.y, bg, c.bg)
+		}
+	})
+}
+
+func assertBoardSize(t testing.TB, gotWidth, gotHeight, wantWidth, wantHeight int) {
+	t.Helper()
+	if gotWidth != wantWidth || gotHeight != wantHeight {
+		t.Errorf("got board size %dx%d, want %dx%d", gotWidth, gotHeight, wantWidth, wantHeight)
+	}
+}
+
+func initScreen(t testing.TB, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	err := screen.Init()
+	snake.AssertNoError(t, err)
+	screen.SetSize(width, height)
+	t.Cleanup(screen.Fini)
+	return screen
+}
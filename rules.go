@@ -0,0 +1,94 @@
+package snake
+
+import (
+	"strings"
+	"time"
+)
+
+const ErrUnknownMode = SnakeErr("snake: unknown game mode")
+
+// obstacleDistance is the number of cells ahead of the snake head
+// on which the survival modes do not place blocks.
+const obstacleDistance = 3
+
+// Rules are the rules of a game mode, which Game applies on each tick.
+// In the time attack modes the game ends when the game time reaches
+// TimeLimit, and the game is won with the score made meanwhile. In the
+// survival modes a block is placed on a free cell every ObstacleEvery ticks,
+// filling the board. With CutTail set the snake hitting its own body bites
+// off its tail from the hit segment onward, losing a point for each segment
+// bitten off, and goes on. In the zen modes the snake can not die: it cuts
+// its tail, and when its head would hit a board side, a block or a hazard it
+// does not wrap around nor turn by itself, but stays in place on each tick
+// until the player turns it towards a free cell.
+// With more than one of Lives the snake respawns when it dies, keeping the
+// score, until the lives are over. In the battle royale modes the arena
+// shrinks every ShrinkEvery ticks, turning its outer ring into walls which
+// kill the snakes caught outside, and with LastAlive set the game is won
+// when all the opponents died.
+type Rules struct {
+	Mode          string        `json:"mode"`
+	TimeLimit     time.Duration `json:"timeLimit,omitempty"`
+	ObstacleEvery int           `json:"obstacleEvery,omitempty"`
+	CutTail       bool          `json:"cutTail,omitempty"`
+	Zen           bool          `json:"zen,omitempty"`
+	Lives         int           `json:"lives,omitempty"`
+	ShrinkEvery   int           `json:"shrinkEvery,omitempty"`
+	LastAlive     bool          `json:"lastAlive,omitempty"`
+}
+
+// Game modes selectable from the mode select menu.
+var (
+	ClassicRules      = Rules{Mode: DefaultMode}
+	TimeAttackRules   = Rules{Mode: "Time attack", TimeLimit: 2 * time.Minute}
+	SurvivalRules     = Rules{Mode: "Survival", ObstacleEvery: 25}
+	ZenRules          = Rules{Mode: "Zen", Zen: true}
+	BattleRoyaleRules = Rules{Mode: "Battle royale", ShrinkEvery: 30, LastAlive: true}
+)
+
+// Modes are the rules of the game modes selectable from the mode select menu,
+// besides the campaign mode. Custom rules can be added before NewController.
+var Modes = []Rules{ClassicRules, TimeAttackRules, SurvivalRules, ZenRules, BattleRoyaleRules}
+
+// valid reports whether the time limit, the obstacle period, the lives
+// and the shrink period are not negative.
+func (r Rules) valid() bool {
+	return r.TimeLimit >= 0 && r.ObstacleEvery >= 0 && r.Lives >= 0 && r.ShrinkEvery >= 0
+}
+
+// cutsTail reports whether the snake hitting its body bites off its tail.
+func (r Rules) cutsTail() bool {
+	return r.CutTail || r.Zen
+}
+
+// timeUp reports whether the game time elapsed reached the time limit.
+func (r Rules) timeUp(elapsed time.Duration) bool {
+	return r.TimeLimit > 0 && elapsed >= r.TimeLimit
+}
+
+// placesObstacleOn reports whether a block is placed on the tick.
+func (r Rules) placesObstacleOn(tick int) bool {
+	return r.ObstacleEvery > 0 && tick%r.ObstacleEvery == 0
+}
+
+// shrinksOn reports whether the arena shrinks on the tick.
+func (r Rules) shrinksOn(tick int) bool {
+	return r.ShrinkEvery > 0 && tick%r.ShrinkEvery == 0
+}
+
+// findRules returns the rules of the mode named mode among Modes.
+func findRules(mode string) (Rules, bool) {
+	for _, r := range Modes {
+		if r.Mode == mode {
+			return r, true
+		}
+	}
+	return Rules{}, false
+}
+
+// sameMode reports whether the mode name matches the mode named mode,
+// ignoring the case and matching dashes with spaces, like "time-attack"
+// and "Time attack".
+func sameMode(name, mode string) bool {
+	return strings.EqualFold(strings.ReplaceAll(name, "-", " "), mode)
+}
@@ -0,0 +1,241 @@
+package snake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestRules(t *testing.T) {
+	// startGame starts a game with rules, skipping the first snake
+	// and food coordinates sent.
+	startGame := func(t *testing.T, s *snake.Snake, rules snake.Rules, food ...snake.Coordinate) (*snake.Game, *snake.FakeClock) {
+		t.Helper()
+		fs := &snake.FoodStub{}
+		values := []snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}}
+		for _, c := range food {
+			values = append(values, snake.FoodStubValue{c, nil})
+		}
+		fs.Seed(values)
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(s, clock, fs)
+		t.Cleanup(g.Quit)
+		g.SetRules(rules)
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		return g, clock
+	}
+
+	t.Run("time attack should win the game when the time is up", func(t *testing.T) {
+		g, clock := startGame(t, snake.NewSnake(60, 60), snake.Rules{Mode: "Time attack", TimeLimit: 2 * time.Microsecond})
+
+		clock.Advance(time.Microsecond)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+		clock.Advance(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, r, _ = snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, true)
+	})
+
+	t.Run("survival should place still blocks off the snake path", func(t *testing.T) {
+		obstacle := snake.Coordinate{X: 10, Y: 10}
+		g, clock := startGame(t, snake.NewSnake(60, 60), snake.Rules{Mode: "Survival", ObstacleEvery: 2}, obstacle, snake.Coordinate{0, 1})
+
+		clock.Advance(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		got := receiveEntities(t, g)
+		assertEntities(t, got, []snake.Entity{{Kind: snake.Block, Position: obstacle, Path: []snake.Coordinate{obstacle}}})
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		g.Restart(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		if s := g.Save(); len(s.Entities) != 0 {
+			t.Errorf("got entities %v after restarting, want none", s.Entities)
+		}
+	})
+
+	t.Run("zen should wait in front of the blocks", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+		defer g.Quit()
+		block := snake.Entity{Kind: snake.Block, Position: snake.Coordinate{35, 30}, Path: []snake.Coordinate{{35, 30}}}
+		snake.AssertNoError(t, g.SetEntities([]snake.Entity{block}))
+		g.SetRules(snake.ZenRules)
+		g.Start(time.Microsecond)
+		receiveEntities(t, g)
+		before, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		assertNoGameEvent(t, g)
+		snake.AssertCoordinates(t, g.Save().Snake, before)
+
+		g.SendMove(snake.Up)
+		clock.Advance(time.Microsecond)
+		receiveEntities(t, g)
+		sc, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+		snake.AssertCoordinate(t, sc[0], snake.Coordinate{before[0].X, before[0].Y - 1})
+	})
+
+	t.Run("zen should bite off the tail hit by the head", func(t *testing.T) {
+		g, clock := startGame(t, snake.NewSnakeOfLength(60, 60, 5), snake.ZenRules)
+
+		var sc []snake.Coordinate
+		for _, d := range []snake.Direction{snake.Up, snake.Right, snake.Down} {
+			g.SendMove(d)
+			clock.Advance(time.Microsecond)
+			var r *bool
+			sc, r, _ = snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+		}
+		assertSnakeLength(t, sc, 3)
+		snake.AssertCoordinates(t, sc, []snake.Coordinate{{sc[1].X, sc[1].Y + 1}, sc[1], {sc[1].X - 1, sc[1].Y}})
+	})
+
+	t.Run("cut tail should bite off the tail and take points off the score", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{35, 30}, nil}, {snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnakeOfLength(60, 60, 5), clock, fs)
+		defer g.Quit()
+		g.SetRules(snake.Rules{Mode: snake.DefaultMode, CutTail: true})
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		assertScore(t, receiveScore(t, g), 1)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		for _, d := range []snake.Direction{snake.Up, snake.Right} {
+			g.SendMove(d)
+			clock.Advance(time.Microsecond)
+			_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+			assertNoGameResult(t, r)
+		}
+		g.SendMove(snake.Down)
+		clock.Advance(time.Microsecond)
+		assertScore(t, receiveScore(t, g), 0)
+		sc, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertNoGameResult(t, r)
+		assertSnakeLength(t, sc, 3)
+	})
+
+	t.Run("should save and restore the rules", func(t *testing.T) {
+		g, _ := startGame(t, snake.NewSnake(60, 60), snake.TimeAttackRules)
+		g.Pause()
+
+		s := g.Save()
+		if s.Rules != snake.TimeAttackRules {
+			t.Fatalf("got rules %+v, want %+v", s.Rules, snake.TimeAttackRules)
+		}
+		restored := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer restored.Quit()
+		snake.AssertNoError(t, restored.Restore(s))
+		if got := restored.Save().Rules; got != snake.TimeAttackRules {
+			t.Errorf("got rules %+v after restoring, want %+v", got, snake.TimeAttackRules)
+		}
+	})
+
+	t.Run("controller should play the selected mode", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+		snake.AssertNoError(t, controller.SetMode("time-attack"))
+		snake.AssertError(t, controller.SetMode("campaign"), snake.ErrUnknownMode)
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		<-game.StartC
+		game.SendSnakeCoordinates(t, []snake.Coordinate{{0, 0}})
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		status := view.GetStatus(t)
+		if status.Mode != snake.TimeAttackRules.Mode || status.TimeLimit != snake.TimeAttackRules.TimeLimit {
+			t.Errorf("got mode %q with time limit %v, want %q with time limit %v", status.Mode, status.TimeLimit, snake.TimeAttackRules.Mode, snake.TimeAttackRules.TimeLimit)
+		}
+	})
+
+	t.Run("controller should display the game time elapsed on the ticks", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		game := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+		view := NewViewSpy()
+		controller := snake.NewController(game, view)
+		controllerClock := snake.NewFakeClock(time.Now())
+		controller.SetClock(controllerClock)
+		snake.AssertNoError(t, controller.SetMode("time-attack"))
+
+		go controller.Start(time.Second)
+		startPlaying(t, view)
+		for i := 0; i < 2; i++ {
+			view.GetSnakeCoordinates(t)
+			view.GetFoodCoordinate(t)
+			view.GetStatus(t)
+		}
+		controllerClock.Advance(time.Minute)
+		clock.Advance(time.Second)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		if got := view.GetStatus(t).Elapsed; got != time.Second {
+			t.Errorf("got %v elapsed after a tick, want %v", got, time.Second)
+		}
+		view.QuitC <- struct{}{}
+		<-controller.WaitForQuitSignal()
+	})
+
+	t.Run("controller should set tail cutting on the game and display its score", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		controller := snake.NewController(game, view)
+
+		go controller.Start(time.Microsecond)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		view.GetMenu(t)
+		view.SendDirection(t, snake.Down)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		assertMenu(t, view.GetMenu(t), snake.SettingsState.String(), "Tail cutting: On")
+		view.SendPause(t)
+		view.GetMenu(t)
+		view.SendSelect(t)
+		<-game.StartC
+
+		long := []snake.Coordinate{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}}
+		game.SendSnakeCoordinates(t, long)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		view.GetStatus(t)
+		game.SendScore(t, 1)
+		game.SendSnakeCoordinates(t, long[:3])
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+		assertStatus(t, view.GetStatus(t), 1, 3)
+		if !game.Rules.CutTail {
+			t.Error("got rules without tail cutting, want tail cutting set on the game")
+		}
+	})
+
+	t.Run("status should display the time left", func(t *testing.T) {
+		s := snake.Status{Mode: "Time attack", Elapsed: 75 * time.Second, TimeLimit: 2 * time.Minute}
+		want := "Score: 0  Length: 0  Speed: 0s  Mode: Time attack  Time left: 00:45"
+		if got := s.String(); got != want {
+			t.Errorf("got status %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package snake
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	ErrCorruptSave      = SaveError("snake: save: corrupt save file")
+	ErrIncompatibleSave = SaveError("snake: save: incompatible save file version")
+	ErrInvalidSave      = SaveError("snake: save: invalid game state")
+	ErrUnsavableGoal    = SaveError("snake: save: win condition can not be saved")
+)
+
+// SaveVersion is the version of the save files written by WriteSave.
+// ReadSave rejects the save files of other versions. Version 2 saves the
+// food random generator state instead of the number of values it drew.
+const SaveVersion = 2
+
+// SaveError type defines save errors
+type SaveError string
+
+func (e SaveError) Error() string {
+	return string(e)
+}
+
+// SaveState is the full state of a game in progress.
+type SaveState struct {
+	Width        int             `json:"width"`
+	Height       int             `json:"height"`
+	Snake        []Coordinate    `json:"snake"`
+	Portals      []Portal        `json:"portals,omitempty"`
+	Entities     []Entity        `json:"entities,omitempty"`
+	Opponents    []OpponentState `json:"opponents,omitempty"`
+	Arena        *Bounds         `json:"arena,omitempty"`
+	Lives        int             `json:"lives,omitempty"`
+	Invulnerable int             `json:"invulnerable,omitempty"`
+	Face         Direction       `json:"face"`
+	Direction    Direction       `json:"direction"`
+	LastTail     *Coordinate     `json:"lastTail"`
+	Food         Coordinate      `json:"food"`
+	Score        int             `json:"score"`
+	Eaten        int             `json:"eaten"`
+	Tick         int             `json:"tick"`
+	RNG          *RNGState       `json:"rng"`
+	Interval     time.Duration   `json:"interval"`
+	Goal         Goal            `json:"goal"`
+	Rules        Rules           `json:"rules"`
+}
+
+// saveFile is the save file content: the game state is checksummed
+// with CRC-32 to detect corrupt files.
+type saveFile struct {
+	Version  int             `json:"version"`
+	Checksum uint32          `json:"checksum"`
+	Game     json.RawMessage `json:"game"`
+}
+
+// Validate returns ErrInvalidSave if s is not a state in which a game can be:
+// the snake, the portals, the entities and the food must be inside the board,
+// the arena, if any, must be inside the board with the snakes and the food inside it,
+// the food and the entities must not be on the snake, on a portal nor on each
+// other, the alive opponents must be inside the board and not on the snake
+// nor on each other, the interval must be positive and the score, eaten,
+// lives, invulnerability, tick, goal targets and rules must be non-negative.
+func (s SaveState) Validate() error {
+	if s.Width < MinEngineWidth || s.Height < MinEngineHeight || s.Interval <= 0 || s.Score < 0 || s.Eaten < 0 || s.Lives < 0 || s.Invulnerable < 0 || s.Tick < 0 || !s.Goal.valid() || !s.Rules.valid() {
+		return ErrInvalidSave
+	}
+	if err := validatePortals(s.Portals, s.Width, s.Height, nil); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+	snake := NewSnake(s.Width, s.Height)
+	snake.portals = s.Portals
+	if err := snake.Restore(s.Snake, s.Face, s.LastTail); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+	if s.Arena != nil {
+		if err := snake.SetBounds(*s.Arena); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSave, err)
+		}
+		if !s.Arena.containsAll(s.Snake) || !s.Arena.Contains(s.Food) {
+			return ErrInvalidSave
+		}
+	}
+	switch s.Direction {
+	case Up, Down, Left, Right:
+	default:
+		return ErrInvalidSave
+	}
+	if !snake.IsValidMove(s.Direction) {
+		return ErrInvalidSave
+	}
+	if s.Food.X < 0 || s.Food.X >= s.Width || s.Food.Y < 0 || s.Food.Y >= s.Height || contains(snake.occupied(), s.Food) {
+		return ErrInvalidSave
+	}
+	if err := validateEntities(s.Entities, s.Width, s.Height, append(snake.occupied(), s.Food)); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSave, err)
+	}
+	occupied := snake.occupied()
+	for _, o := range s.Opponents {
+		if err := o.validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSave, err)
+		}
+		if !o.Alive {
+			continue
+		}
+		r := newRival(o.Opponent, snake)
+		if err := r.snake.Restore(o.Snake, o.Face, nil); err != nil || crosses(o.Snake, occupied) || !snake.bounds.containsAll(o.Snake) {
+			return fmt.Errorf("%w: %v", ErrInvalidSave, ErrInvalidOpponents)
+		}
+		occupied = append(append([]Coordinate{}, occupied...), o.Snake...)
+	}
+	return nil
+}
+
+// WriteSave writes s to w in a versioned save file.
+func WriteSave(w io.Writer, s SaveState) error {
+	game, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(saveFile{SaveVersion, crc32.ChecksumIEEE(game), game})
+}
+
+// ReadSave reads a save file from r. It returns ErrCorruptSave if the file
+// can not be decoded or its checksum does not match, ErrIncompatibleSave
+// if it has another version, and ErrInvalidSave if the game state is invalid.
+func ReadSave(r io.Reader) (SaveState, error) {
+	var file saveFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return SaveState{}, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	if file.Version != SaveVersion {
+		return SaveState{}, ErrIncompatibleSave
+	}
+	if crc32.ChecksumIEEE(file.Game) != file.Checksum {
+		return SaveState{}, ErrCorruptSave
+	}
+	var s SaveState
+	if err := json.Unmarshal(file.Game, &s); err != nil {
+		return SaveState{}, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	if err := s.Validate(); err != nil {
+		return SaveState{}, err
+	}
+	return s, nil
+}
+
+// SaveFile writes s to the save file at path, replacing it
+// only after the new save is completely written.
+func SaveFile(path string, s SaveState) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := WriteSave(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadFile reads the save file at path.
+func LoadFile(path string) (SaveState, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return SaveState{}, err
+	}
+	defer f.Close()
+	return ReadSave(f)
+}
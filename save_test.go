@@ -0,0 +1,136 @@
+package snake_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestSave(t *testing.T) {
+	t.Run("should read the written save", func(t *testing.T) {
+		want := newSaveState(t)
+		var buf bytes.Buffer
+
+		err := snake.WriteSave(&buf, want)
+		snake.AssertNoError(t, err)
+		got, err := snake.ReadSave(&buf)
+		snake.AssertNoError(t, err)
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got state %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("should save and load files", func(t *testing.T) {
+		want := newSaveState(t)
+		path := filepath.Join(t.TempDir(), "save.json")
+
+		err := snake.SaveFile(path, want)
+		snake.AssertNoError(t, err)
+		got, err := snake.LoadFile(path)
+		snake.AssertNoError(t, err)
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got state %+v, want %+v", got, want)
+		}
+		entries, _ := os.ReadDir(filepath.Dir(path))
+		if len(entries) != 1 {
+			t.Errorf("got %d files, want only the save file", len(entries))
+		}
+	})
+
+	t.Run("should reject corrupt saves", func(t *testing.T) {
+		var buf bytes.Buffer
+		snake.WriteSave(&buf, newSaveState(t))
+		tampered := strings.Replace(buf.String(), `"score":2`, `"score":9`, 1)
+
+		cases := map[string]string{
+			"truncated": buf.String()[:buf.Len()/2],
+			"tampered":  tampered,
+			"not json":  "snake",
+		}
+		for name, file := range cases {
+			_, err := snake.ReadSave(strings.NewReader(file))
+			if !errors.Is(err, snake.ErrCorruptSave) {
+				t.Errorf("got error %v reading %s save, want %v", err, name, snake.ErrCorruptSave)
+			}
+		}
+	})
+
+	t.Run("should reject saves of other versions", func(t *testing.T) {
+		var buf bytes.Buffer
+		snake.WriteSave(&buf, newSaveState(t))
+		version := fmt.Sprintf(`"version":%d`, snake.SaveVersion)
+		file := strings.Replace(buf.String(), version, fmt.Sprintf(`"version":%d`, snake.SaveVersion+1), 1)
+
+		_, err := snake.ReadSave(strings.NewReader(file))
+		snake.AssertError(t, err, snake.ErrIncompatibleSave)
+	})
+
+	t.Run("should reject invalid game states", func(t *testing.T) {
+		valid := newSaveState(t)
+		cases := map[string]func(s *snake.SaveState){
+			"snake out of board":     func(s *snake.SaveState) { s.Snake[0].X = -1 },
+			"disconnected snake":     func(s *snake.SaveState) { s.Snake[2] = snake.Coordinate{X: 0, Y: 0} },
+			"head against face":      func(s *snake.SaveState) { s.Face = snake.Right },
+			"food on snake":          func(s *snake.SaveState) { s.Food = s.Snake[1] },
+			"food out of board":      func(s *snake.SaveState) { s.Food = snake.Coordinate{X: 10, Y: 0} },
+			"reversing direction":    func(s *snake.SaveState) { s.Direction = snake.Down },
+			"zero interval":          func(s *snake.SaveState) { s.Interval = 0 },
+			"negative score":         func(s *snake.SaveState) { s.Score = -1 },
+			"negative eaten":         func(s *snake.SaveState) { s.Eaten = -1 },
+			"negative goal":          func(s *snake.SaveState) { s.Goal.Length = -1 },
+			"too small board":        func(s *snake.SaveState) { s.Width = 1 },
+			"last tail out of reach": func(s *snake.SaveState) { s.LastTail = &snake.Coordinate{X: 0, Y: 0} },
+			"food on portal":         func(s *snake.SaveState) { s.Food = s.Portals[0].A },
+			"entity on food":         func(s *snake.SaveState) { s.Entities = []snake.Entity{{Kind: snake.Mouse, Position: s.Food}} },
+			"entity on snake":        func(s *snake.SaveState) { s.Entities = []snake.Entity{{Kind: snake.Mouse, Position: s.Snake[2]}} },
+			"portal out of board": func(s *snake.SaveState) {
+				s.Portals = []snake.Portal{{A: snake.Coordinate{X: 1, Y: 8}, B: snake.Coordinate{X: 10, Y: 8}}}
+			},
+			"snake on portal": func(s *snake.SaveState) { s.Portals = []snake.Portal{{A: s.Snake[1], B: snake.Coordinate{X: 8, Y: 8}}} },
+		}
+		for name, invalidate := range cases {
+			s := valid
+			s.Snake = append([]snake.Coordinate{}, valid.Snake...)
+			rng := *valid.RNG
+			s.RNG = &rng
+			invalidate(&s)
+			var buf bytes.Buffer
+			snake.WriteSave(&buf, s)
+
+			_, err := snake.ReadSave(&buf)
+			if !errors.Is(err, snake.ErrInvalidSave) {
+				t.Errorf("got error %v reading save with %s, want %v", err, name, snake.ErrInvalidSave)
+			}
+		}
+	})
+}
+
+// newSaveState returns a valid state of a game on a 10x10 board with a portal.
+func newSaveState(t testing.TB) snake.SaveState {
+	t.Helper()
+	return snake.SaveState{
+		Width:     10,
+		Height:    10,
+		Snake:     []snake.Coordinate{{X: 5, Y: 4}, {X: 5, Y: 5}, {X: 6, Y: 5}},
+		Portals:   []snake.Portal{{A: snake.Coordinate{X: 1, Y: 8}, B: snake.Coordinate{X: 8, Y: 8}}},
+		Face:      snake.Up,
+		Direction: snake.Left,
+		LastTail:  &snake.Coordinate{X: 7, Y: 5},
+		Food:      snake.Coordinate{X: 1, Y: 1},
+		Score:     2,
+		Eaten:     2,
+		Tick:      12,
+		RNG:       &snake.RNGState{Seed: 42, State: 6},
+		Interval:  200 * time.Millisecond,
+	}
+}
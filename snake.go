@@ -9,6 +9,8 @@ const (
 	ErrHeadOutOfBoard             = SnakeErr("snake: head out of board")
 	ErrSnakeMustMoveBeforeGrowing = SnakeErr("snake: must move before growing")
 	ErrHeadHitBody                = SnakeErr("snake: head hit body")
+	ErrInvalidSnake               = SnakeErr("snake: invalid snake coordinates")
+	ErrInvalidTruncate            = SnakeErr("snake: invalid truncate index")
 )
 
 const (
@@ -45,6 +47,8 @@ type Snake struct {
 	coordinates   []Coordinate
 	lastTail      *Coordinate
 	faceDirection Direction
+	portals       []Portal
+	bounds        Bounds
 }
 
 // NewSnake returns a new Snake struct pointer initializing snake coordinates
@@ -56,19 +60,25 @@ func NewSnake(width, height int) *Snake {
 // NewSnakeOfLength returns a new Snake struct pointer initializing snake coordinates
 // and setting width and height of the board and snake length.
 func NewSnakeOfLength(width, height, length int) *Snake {
-	s := &Snake{width: width, height: height, initialLength: length}
+	s := &Snake{width: width, height: height, initialLength: length, bounds: fullBounds(width, height)}
 	s.initCoordinates()
 	s.faceDirection = Left
 	return s
 }
 
 func (s *Snake) initCoordinates() {
-	s.coordinates = make([]Coordinate, s.initialLength)
+	s.coordinates = s.startCoordinates()
+}
+
+// startCoordinates returns the coordinates on which Reset places the snake.
+func (s *Snake) startCoordinates() []Coordinate {
+	c := make([]Coordinate, s.initialLength)
 	startX := int(math.Floor(float64(s.width) * 0.6))
 	startY := int(math.Floor(float64(s.height) * 0.5))
 	for i := 0; i < s.initialLength; i++ {
-		s.coordinates[i] = Coordinate{startX + i, startY}
+		c[i] = Coordinate{startX + i, startY}
 	}
+	return c
 }
 
 // GetCoordinates returns the snake internal coordinates.
@@ -77,13 +87,15 @@ func (s *Snake) GetCoordinates() []Coordinate {
 }
 
 // Move moves the snake head towards direction d, cutting tail coordinate
-// and appending new coordinate on head. Returns ErrHeadOutOfBoard error
-// when head would move out of the board. Returns SnakeInvalidMoveErr error if
+// and appending new coordinate on head. If the head moves on a portal cell
+// it emerges from the cell next to the partner portal cell towards d,
+// so consecutive coordinates are not adjacent. Returns ErrHeadOutOfBoard error
+// when head would move out of the board, or out of the snake bounds. Returns SnakeInvalidMoveErr error if
 // direction d is inconsistent with face direction. Returns ErrHeadHitBody error
 // if the head would move above a body coordinate.
 func (s *Snake) Move(d Direction) error {
 	head := s.setHead(d)
-	if head.X < 0 || head.X >= s.width || head.Y < 0 || head.Y >= s.height {
+	if !s.bounds.Contains(head) {
 		return ErrHeadOutOfBoard
 	}
 	if !s.IsValidMove(d) {
@@ -101,18 +113,55 @@ func (s *Snake) Move(d Direction) error {
 }
 
 func (s *Snake) setHead(d Direction) Coordinate {
-	head := s.coordinates[0]
-	switch d {
-	case Up:
-		head.Y--
-	case Down:
-		head.Y++
-	case Left:
-		head.X--
-	case Right:
-		head.X++
+	return stepThrough(s.portals, s.coordinates[0], d)
+}
+
+// Truncate removes the segments from the i-th onward, where the head is the
+// 0-th segment. The first segment removed becomes the last tail, on which
+// Grow grows the snake back. It returns ErrInvalidTruncate if i is not
+// between 1 and the snake length.
+func (s *Snake) Truncate(i int) error {
+	if i < 1 || i > len(s.coordinates) {
+		return ErrInvalidTruncate
+	}
+	if i < len(s.coordinates) {
+		lastTail := s.coordinates[i]
+		s.lastTail = &lastTail
+		s.coordinates = s.coordinates[:i]
+	}
+	return nil
+}
+
+// respawn places the snake with its initial length facing left on the free
+// coordinates inside its bounds found by respawnCoordinates, off the occupied coordinates.
+// It returns false if the snake does not fit the board.
+func (s *Snake) respawn(occupied []Coordinate) bool {
+	return s.spawn(s.startCoordinates()[0], occupied)
+}
+
+// spawn places the snake like respawn, trying the head cell first.
+func (s *Snake) spawn(head Coordinate, occupied []Coordinate) bool {
+	c, ok := respawnCoordinates([]Coordinate{head}, s.initialLength, s.bounds, occupied)
+	if !ok {
+		return false
 	}
-	return head
+	s.coordinates, s.faceDirection, s.lastTail = c, Left, nil
+	return true
+}
+
+// biteTail truncates the snake at the body segment which the head moving
+// towards d would hit, so that the head can move there.
+// It returns the number of segments removed.
+func (s *Snake) biteTail(d Direction) int {
+	head := s.setHead(d)
+	for i, c := range s.coordinates[:len(s.coordinates)-1] {
+		if c == head {
+			n := len(s.coordinates) - i
+			s.Truncate(i)
+			return n
+		}
+	}
+	return 0
 }
 
 // IsValidMove tests if direction is valid for next snake move.
@@ -141,9 +190,120 @@ func (s *Snake) Face() Direction {
 	return s.faceDirection
 }
 
-// Reset resets snake internal coordinates, face direction and last tail coordinate pointer.
+// Reset resets snake internal coordinates, face direction, last tail
+// coordinate pointer and bounds.
 func (s *Snake) Reset() {
 	s.initCoordinates()
+	s.bounds = fullBounds(s.width, s.height)
 	s.faceDirection = Left
 	s.lastTail = nil
 }
+
+// Resize sets the board width and height, removing the portals which
+// do not fit the board or are where Reset places the snake. The snake
+// coordinates are not changed, Reset should be called to place the
+// snake on the resized board.
+func (s *Snake) Resize(width, height int) {
+	s.width = width
+	s.height = height
+	s.bounds = fullBounds(width, height)
+	portals := s.portals[:0:0]
+	start := s.startCoordinates()
+	for _, p := range s.portals {
+		if validatePortals([]Portal{p}, width, height, start) == nil {
+			portals = append(portals, p)
+		}
+	}
+	s.portals = portals
+}
+
+// SetBounds sets the board cells inside which the snake moves, which
+// Reset and Resize set back to the whole board. It returns
+// ErrInvalidBounds if b is empty or not inside the board.
+func (s *Snake) SetBounds(b Bounds) error {
+	if !b.valid(s.width, s.height) {
+		return ErrInvalidBounds
+	}
+	s.bounds = b
+	return nil
+}
+
+// Bounds returns the board cells inside which the snake moves.
+func (s *Snake) Bounds() Bounds {
+	return s.bounds
+}
+
+// SetPortals sets the board portals, which the snake moves through.
+// It returns ErrInvalidPortals if a portal cell is outside the board,
+// is used twice, is adjacent to a cell of another portal or is under
+// the snake.
+func (s *Snake) SetPortals(portals []Portal) error {
+	if err := validatePortals(portals, s.width, s.height, s.coordinates); err != nil {
+		return err
+	}
+	s.portals = append([]Portal{}, portals...)
+	return nil
+}
+
+// Portals returns the board portals.
+func (s *Snake) Portals() []Portal {
+	return s.portals
+}
+
+// occupied returns the snake coordinates and the portal cells,
+// on which the food can not be generated.
+func (s *Snake) occupied() []Coordinate {
+	if len(s.portals) == 0 {
+		return s.coordinates
+	}
+	return append(append([]Coordinate{}, s.coordinates...), portalCells(s.portals)...)
+}
+
+// LastTail returns the tail coordinate cut by the last move,
+// or nil if the snake did not move since the last reset.
+func (s *Snake) LastTail() *Coordinate {
+	return s.lastTail
+}
+
+// Restore sets the snake coordinates, face direction and last tail
+// coordinate. It returns ErrInvalidSnake if the coordinates are not
+// a chain of cells inside the board, adjacent or linked by a portal,
+// with the head towards face, if a coordinate is a portal cell or if the
+// last tail is not the tail or linked to it.
+func (s *Snake) Restore(c []Coordinate, face Direction, lastTail *Coordinate) error {
+	switch face {
+	case Up, Down, Left, Right:
+	default:
+		return ErrInvalidSnake
+	}
+	if len(c) == 0 {
+		return ErrInvalidSnake
+	}
+	for i, coordinate := range c {
+		if coordinate.X < 0 || coordinate.X >= s.width || coordinate.Y < 0 || coordinate.Y >= s.height ||
+			contains(c[:i], coordinate) {
+			return ErrInvalidSnake
+		}
+		if _, ok := partner(s.portals, coordinate); ok {
+			return ErrInvalidSnake
+		}
+		if i > 0 && link(s.portals, coordinate, c[i-1]) == 0 {
+			return ErrInvalidSnake
+		}
+	}
+	if len(c) > 1 && link(s.portals, c[1], c[0]) != face {
+		return ErrInvalidSnake
+	}
+	tail := c[len(c)-1]
+	if lastTail != nil && *lastTail != tail && link(s.portals, *lastTail, tail) == 0 {
+		return ErrInvalidSnake
+	}
+	s.coordinates = append([]Coordinate{}, c...)
+	s.faceDirection = face
+	s.lastTail = nil
+	if lastTail != nil {
+		t := *lastTail
+		s.lastTail = &t
+	}
+	return nil
+}
@@ -212,6 +212,17 @@ func TestSnake(t *testing.T) {
 		snake.AssertError(t, gotErr, snake.ErrSnakeMustMoveBeforeGrowing)
 	})
 
+	t.Run("should reset on resized board", func(t *testing.T) {
+		s := snake.NewSnake(60, 60)
+
+		s.Resize(10, 10)
+		s.Reset()
+
+		got := s.GetCoordinates()
+		want := []snake.Coordinate{{6, 5}, {7, 5}, {8, 5}}
+		snake.AssertCoordinates(t, got, want)
+	})
+
 	t.Run("move should return error when head would hit body", func(t *testing.T) {
 		s := snake.NewSnakeOfLength(10, 10, 6)
 		err := s.Move(snake.Up)
@@ -233,4 +244,52 @@ func TestSnake(t *testing.T) {
 		err = s.Move(snake.Left)
 		snake.AssertNoError(t, err)
 	})
+	t.Run("should restore coordinates, face and last tail", func(t *testing.T) {
+		s := snake.NewSnake(10, 10)
+		coordinates := []snake.Coordinate{{5, 4}, {5, 5}, {6, 5}}
+		lastTail := snake.Coordinate{7, 5}
+
+		err := s.Restore(coordinates, snake.Up, &lastTail)
+		snake.AssertNoError(t, err)
+
+		snake.AssertCoordinates(t, s.GetCoordinates(), coordinates)
+		snake.AssertDirection(t, s.Face(), snake.Up)
+		snake.AssertCoordinate(t, *s.LastTail(), lastTail)
+		snake.AssertNoError(t, s.Grow())
+		snake.AssertCoordinates(t, s.GetCoordinates(), append(coordinates, lastTail))
+	})
+
+	t.Run("should not restore invalid coordinates", func(t *testing.T) {
+		s := snake.NewSnake(10, 10)
+		want := s.GetCoordinates()
+
+		err := s.Restore([]snake.Coordinate{{5, 4}, {5, 6}}, snake.Up, nil)
+		snake.AssertError(t, err, snake.ErrInvalidSnake)
+
+		snake.AssertCoordinates(t, s.GetCoordinates(), want)
+	})
+
+	t.Run("should truncate at an index, growing back on the cut tail", func(t *testing.T) {
+		s := snake.NewSnakeOfLength(10, 10, 5)
+		coordinates := s.GetCoordinates()
+
+		err := s.Truncate(2)
+		snake.AssertNoError(t, err)
+		snake.AssertCoordinates(t, s.GetCoordinates(), coordinates[:2])
+		snake.AssertCoordinate(t, *s.LastTail(), coordinates[2])
+		snake.AssertNoError(t, s.Grow())
+		snake.AssertCoordinates(t, s.GetCoordinates(), coordinates[:3])
+	})
+
+	t.Run("should not truncate at invalid indexes", func(t *testing.T) {
+		s := snake.NewSnake(10, 10)
+		want := s.GetCoordinates()
+
+		for _, i := range []int{-1, 0, 4} {
+			err := s.Truncate(i)
+			snake.AssertError(t, err, snake.ErrInvalidTruncate)
+		}
+		snake.AssertNoError(t, s.Truncate(3))
+		snake.AssertCoordinates(t, s.GetCoordinates(), want)
+	})
 }
@@ -0,0 +1,276 @@
+package snake
+
+import (
+	"sync"
+	"time"
+)
+
+// SpectatorMode is the game mode displayed in the spectators status bar.
+const SpectatorMode = "Spectating"
+
+// GameSnapshot is the state of a game as seen by its spectators.
+type GameSnapshot struct {
+	Snake     []Coordinate
+	Face      Direction
+	Food      *Coordinate
+	Entities  []Entity
+	Opponents []OpponentState
+	Arena     Bounds
+	Life      *Life
+	Result    *bool
+	Score     int
+	Speed     time.Duration
+	Start     time.Time
+}
+
+// hub fans out the game state to the spectators. Each spectator receives
+// the last snapshot only, so a slow spectator skips intermediate states
+// instead of slowing the game down.
+type hub struct {
+	clock      Clock
+	snapshot   GameSnapshot
+	spectators map[*Spectator]struct{}
+	closed     bool
+	mu         sync.Mutex
+}
+
+func newHub(clock Clock) *hub {
+	return &hub{clock: clock, spectators: make(map[*Spectator]struct{})}
+}
+
+// start publishes the first snake coordinates and face direction of a game
+// played every d, starting from score.
+func (h *hub) start(c []Coordinate, face Direction, d time.Duration, score int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot = GameSnapshot{Snake: append([]Coordinate{}, c...), Face: face, Score: score, Speed: d, Start: h.clock.Now()}
+	h.publish()
+}
+
+// snake publishes the snake coordinates and face direction.
+func (h *hub) snake(c []Coordinate, face Direction) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Snake, h.snapshot.Face = append([]Coordinate{}, c...), face
+	h.publish()
+}
+
+// food publishes the food coordinate.
+func (h *hub) food(c Coordinate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Food = &c
+	h.publish()
+}
+
+// score publishes the score.
+func (h *hub) score(score int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Score = score
+	h.publish()
+}
+
+// entities publishes the entities.
+func (h *hub) entities(e []Entity) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Entities = e
+	h.publish()
+}
+
+// opponents publishes the opponents.
+func (h *hub) opponents(o []OpponentState) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Opponents = o
+	h.publish()
+}
+
+// arena publishes the arena bounds.
+func (h *hub) arena(b Bounds) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Arena = b
+	h.publish()
+}
+
+// life publishes the lives.
+func (h *hub) life(l Life) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Life = &l
+	h.publish()
+}
+
+func (h *hub) result(r bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.snapshot.Result = &r
+	h.publish()
+}
+
+// publish sends the snapshot to the spectators.
+// It should be called with the hub mutex locked.
+func (h *hub) publish() {
+	for s := range h.spectators {
+		s.send(h.snapshot)
+	}
+}
+
+// watch adds a spectator rendering the game on view,
+// sending it the current snapshot first.
+func (h *hub) watch(view ViewHandler) *Spectator {
+	s := &Spectator{
+		view:      view,
+		snapshotC: make(chan GameSnapshot, 1),
+		stopC:     make(chan struct{}),
+		doneC:     make(chan struct{}),
+		hub:       h,
+	}
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(s.doneC)
+		return s
+	}
+	h.spectators[s] = struct{}{}
+	if h.snapshot.Snake != nil {
+		s.send(h.snapshot)
+	}
+	h.mu.Unlock()
+	go s.routine()
+	return s
+}
+
+func (h *hub) remove(s *Spectator) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.spectators, s)
+}
+
+// close stops all the spectators and refuses new ones.
+func (h *hub) close() {
+	h.mu.Lock()
+	h.closed = true
+	spectators := make([]*Spectator, 0, len(h.spectators))
+	for s := range h.spectators {
+		spectators = append(spectators, s)
+	}
+	h.mu.Unlock()
+	for _, s := range spectators {
+		s.Stop()
+	}
+}
+
+// Spectator renders a game on a read-only view: the view inputs are
+// discarded, except the quit signal which stops the spectator.
+type Spectator struct {
+	view      ViewHandler
+	snapshotC chan GameSnapshot
+	stopC     chan struct{}
+	doneC     chan struct{}
+	stopOnce  sync.Once
+	hub       *hub
+}
+
+// send queues snapshot, replacing the snapshot queued before if it
+// has not been rendered yet. It should be called with the hub mutex locked.
+func (s *Spectator) send(snapshot GameSnapshot) {
+	select {
+	case <-s.snapshotC:
+	default:
+	}
+	s.snapshotC <- snapshot
+}
+
+func (s *Spectator) routine() {
+	defer close(s.doneC)
+	var selectC, pauseC, saveC <-chan struct{}
+	if v, ok := s.view.(menuView); ok {
+		selectC, pauseC = v.ReceiveSelectSignal(), v.ReceivePauseSignal()
+	}
+	if v, ok := s.view.(saveView); ok {
+		saveC = v.ReceiveSaveSignal()
+	}
+	var resizeC <-chan Size
+	if v, ok := s.view.(resizeView); ok {
+		resizeC = v.ReceiveResize()
+	}
+	for {
+		select {
+		case snapshot := <-s.snapshotC:
+			s.render(snapshot)
+		case <-s.view.ReceiveDirection():
+		case <-selectC:
+		case <-pauseC:
+		case <-saveC:
+		case <-s.view.ReceiveNewGameSignal():
+		case <-resizeC:
+		case <-s.view.ReceiveQuitSignal():
+			s.hub.remove(s)
+			return
+		case <-s.stopC:
+			return
+		}
+	}
+}
+
+// render refreshes the view with snapshot, then displays
+// win or lose if the game is over. The entities are drawn
+// if the view implements a SetEntities([]Entity) method, the
+// opponents if it implements a SetOpponents([]OpponentState) method,
+// the arena walls if it implements a SetArena(Bounds) method, and the
+// snake face if it implements a SetFace(Direction) method.
+func (s *Spectator) render(snapshot GameSnapshot) {
+	if v, ok := s.view.(entityView); ok {
+		v.SetEntities(snapshot.Entities)
+	}
+	if v, ok := s.view.(opponentView); ok {
+		v.SetOpponents(snapshot.Opponents)
+	}
+	if v, ok := s.view.(arenaView); ok {
+		v.SetArena(snapshot.Arena)
+	}
+	if v, ok := s.view.(faceView); ok {
+		v.SetFace(snapshot.Face)
+	}
+	lives := 0
+	if snapshot.Life != nil {
+		lives = snapshot.Life.Left
+		if v, ok := s.view.(lifeView); ok {
+			v.SetInvulnerable(snapshot.Life.Invulnerable)
+		}
+	}
+	refreshFrame(s.view, &snapshot.Snake, snapshot.Food, Status{
+		Score:    snapshot.Score,
+		Length:   len(snapshot.Snake),
+		Speed:    snapshot.Speed,
+		Mode:     SpectatorMode,
+		Elapsed:  s.hub.clock.Now().Sub(snapshot.Start),
+		Lives:    lives,
+		HasLives: snapshot.Life != nil,
+	})
+	if snapshot.Result == nil {
+		return
+	}
+	if *snapshot.Result {
+		s.view.DisplayWin()
+	} else {
+		s.view.DisplayLose()
+	}
+}
+
+// Stop stops rendering the game. It can be called more than once.
+func (s *Spectator) Stop() {
+	s.stopOnce.Do(func() {
+		s.hub.remove(s)
+		close(s.stopC)
+	})
+}
+
+// Done returns a channel which is closed when the spectator stops,
+// after Stop, after the view quit signal or after the game quits.
+func (s *Spectator) Done() <-chan struct{} {
+	return s.doneC
+}
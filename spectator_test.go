@@ -0,0 +1,132 @@
+package snake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestSpectator(t *testing.T) {
+	t.Run("should send the current game to late spectators", func(t *testing.T) {
+		g, clock := initWatchedGame(t)
+		clock.Advance(time.Millisecond)
+		want, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+		view := NewViewSpy()
+
+		g.Watch(view)
+
+		snake.AssertCoordinates(t, *view.GetSnakeCoordinates(t), want)
+		snake.AssertCoordinate(t, *view.GetFoodCoordinate(t), snake.Coordinate{X: 0, Y: 0})
+		status := view.GetStatus(t)
+		if status.Mode != snake.SpectatorMode || status.Length != 3 || status.Speed != time.Millisecond {
+			t.Errorf("got status %+v, want a spectator status", status)
+		}
+	})
+
+	t.Run("should send the game changes to many spectators", func(t *testing.T) {
+		g, clock := initWatchedGame(t)
+		views := []*ViewSpy{NewViewSpy(), NewViewSpy()}
+		for _, view := range views {
+			g.Watch(view)
+			view.GetSnakeCoordinates(t)
+			view.GetFoodCoordinate(t)
+		}
+
+		clock.Advance(time.Millisecond)
+		want, _, _ := snake.WaitAndReceiveGameChannels(t, g)
+
+		for _, view := range views {
+			snake.AssertCoordinates(t, *view.GetSnakeCoordinates(t), want)
+			view.GetFoodCoordinate(t)
+		}
+	})
+
+	t.Run("should display the game result to spectators", func(t *testing.T) {
+		g, clock := initWatchedGame(t)
+		view := NewViewSpy()
+		g.Watch(view)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+
+		g.SendMove(snake.Up)
+		for i := 0; i < 40; i++ {
+			clock.Advance(time.Millisecond)
+			if _, r, _ := snake.WaitAndReceiveGameChannels(t, g); r != nil {
+				break
+			}
+			view.GetSnakeCoordinates(t)
+			view.GetFoodCoordinate(t)
+		}
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+
+		<-view.LoseC
+	})
+
+	t.Run("should stop the spectator on the view quit signal", func(t *testing.T) {
+		g, _ := initWatchedGame(t)
+		view := NewViewSpy()
+		spectator := g.Watch(view)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+
+		view.QuitC <- struct{}{}
+
+		assertSpectatorDone(t, spectator)
+	})
+
+	t.Run("should discard the spectator inputs", func(t *testing.T) {
+		g, _ := initWatchedGame(t)
+		view := NewViewSpy()
+		spectator := g.Watch(view)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+
+		view.SendDirection(t, snake.Up)
+		view.SendSelect(t)
+		spectator.Stop()
+
+		assertSpectatorDone(t, spectator)
+	})
+
+	t.Run("should stop the spectators when the game quits", func(t *testing.T) {
+		s := snake.NewSnake(60, 60)
+		clock := snake.NewFakeClock(time.Now())
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{Coord: snake.Coordinate{X: 0, Y: 0}}})
+		g := snake.NewGame(s, clock, fs)
+		g.Start(time.Millisecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		view := NewViewSpy()
+		spectator := g.Watch(view)
+		view.GetSnakeCoordinates(t)
+		view.GetFoodCoordinate(t)
+
+		g.Quit()
+
+		assertSpectatorDone(t, spectator)
+	})
+}
+
+// initWatchedGame starts a game on a 60x60 board,
+// receiving its first snake and food coordinates.
+func initWatchedGame(t testing.TB) (*snake.Game, *snake.FakeClock) {
+	t.Helper()
+	s := snake.NewSnake(60, 60)
+	clock := snake.NewFakeClock(time.Now())
+	fs := &snake.FoodStub{}
+	fs.Seed([]snake.FoodStubValue{{Coord: snake.Coordinate{X: 0, Y: 0}}})
+	g := snake.NewGame(s, clock, fs)
+	g.Start(time.Millisecond)
+	snake.WaitAndReceiveGameChannels(t, g)
+	snake.WaitAndReceiveGameChannels(t, g)
+	t.Cleanup(g.Quit)
+	return g, clock
+}
+
+func assertSpectatorDone(t testing.TB, s *snake.Spectator) {
+	t.Helper()
+	<-s.Done()
+}
@@ -0,0 +1,93 @@
+package snake
+
+import "time"
+
+// State is a screen of the controller state machine.
+type State int
+
+const (
+	TitleState State = iota
+	ModeSelectState
+	SettingsState
+	PlayingState
+	PausedState
+	GameOverState
+	LeaderboardState
+	HelpState
+	LevelSelectState
+	LevelIntroState
+)
+
+func (s State) String() string {
+	switch s {
+	case TitleState:
+		return "Title"
+	case ModeSelectState:
+		return "Mode select"
+	case SettingsState:
+		return "Settings"
+	case PlayingState:
+		return "Playing"
+	case PausedState:
+		return "Paused"
+	case GameOverState:
+		return "Game over"
+	case LeaderboardState:
+		return "Leaderboard"
+	case HelpState:
+		return "Help"
+	case LevelSelectState:
+		return "Level select"
+	case LevelIntroState:
+		return "Level intro"
+	}
+	return "Invalid state"
+}
+
+// Title menu items.
+const (
+	PlayItem        = "Play"
+	ModeItem        = "Mode"
+	SettingsItem    = "Settings"
+	LeaderboardItem = "Leaderboard"
+	HelpItem        = "Help"
+	QuitItem        = "Quit"
+)
+
+// Paused menu items.
+const (
+	ResumeItem   = "Resume"
+	RestartItem  = "Restart"
+	SaveItem     = "Save"
+	MainMenuItem = "Main menu"
+)
+
+// Level intro menu items.
+const StartItem = "Start"
+
+// SavedMessage is displayed on the paused menu after the game is saved.
+const SavedMessage = "Game saved."
+
+// BackItem returns to the title menu.
+const BackItem = "Back"
+
+// LeaderboardSize is the number of best scores kept in the leaderboard.
+const LeaderboardSize = 10
+
+// Speeds are the game intervals selectable from the settings menu.
+var Speeds = []time.Duration{
+	300 * time.Millisecond,
+	200 * time.Millisecond,
+	120 * time.Millisecond,
+	60 * time.Millisecond,
+}
+
+// HelpText is displayed on the help screen.
+var HelpText = []string{
+	"Use the arrow keys to move the snake.",
+	"Eat the food to grow, do not hit the walls or your own body.",
+	"In the zen mode the snake stops in front of the walls until you turn it.",
+	"Press P or ESC to pause, SPACEBAR to start a new game, Q or CTRL+C to quit.",
+	"Press S to save the game, run with -resume to continue it.",
+	"In the menus use the arrow keys and ENTER.",
+}
@@ -0,0 +1,41 @@
+package snake
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status holds the game information displayed in the view status bar.
+// When the TimeLimit of the time attack modes is set, the status bar
+// displays the time left instead of the time elapsed. The Lives left are
+// displayed when HasLives is set, as the game has lives.
+type Status struct {
+	Score     int
+	Length    int
+	Speed     time.Duration
+	Mode      string
+	Elapsed   time.Duration
+	TimeLimit time.Duration
+	Lives     int
+	HasLives  bool
+}
+
+// String formats the status as a single status bar line.
+func (s Status) String() string {
+	label, t := "Time", s.Elapsed
+	if s.TimeLimit > 0 {
+		label, t = "Time left", s.TimeLimit-s.Elapsed
+		if t < 0 {
+			t = 0
+		}
+	}
+	t = t.Truncate(time.Second)
+	minutes := int(t / time.Minute)
+	seconds := int((t % time.Minute) / time.Second)
+	status := fmt.Sprintf("Score: %d  Length: %d  Speed: %v  Mode: %s  %s: %02d:%02d",
+		s.Score, s.Length, s.Speed, s.Mode, label, minutes, seconds)
+	if s.HasLives {
+		status += fmt.Sprintf("  Lives: %d", s.Lives)
+	}
+	return status
+}
@@ -2,6 +2,7 @@ package snake
 
 import (
 	"reflect"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -54,22 +55,46 @@ func AssertDirection(t testing.TB, got Direction, want Direction) {
 }
 
 // WaitAndReceiveGameChannels returns a ([]Coordinate, *bool, *Coordinate) tuple with snake coordinates
-// or game result or food coordinate. It waits to receive values from the game exposed receive channels.
+// or game result or food coordinate. It waits to receive values from the game exposed receive channels,
+// skipping the scores received meanwhile. It fails t if none of them is received before the test deadline.
 func WaitAndReceiveGameChannels(t testing.TB, g *Game) (snakeCoordinate []Coordinate, gameResult *bool, foodCoordinate *Coordinate) {
 	t.Helper()
-	select {
-	case c := <-g.ReceiveSnakeCoordinates():
-		return c, nil, nil
-	case r := <-g.ReceiveGameResult():
-		return nil, &r, nil
-	case f := <-g.ReceiveFoodCoordinate():
-		return nil, nil, &f
-	case <-time.After(time.Millisecond * 5):
-		t.Fatal("got nothing from game channels, want snake coordinates or food coordinate or game result")
-		return nil, nil, nil
+	deadline, stop := testDeadline(t)
+	defer stop()
+	for {
+		select {
+		case c := <-g.ReceiveSnakeCoordinates():
+			return c, nil, nil
+		case r := <-g.ReceiveGameResult():
+			return nil, &r, nil
+		case f := <-g.ReceiveFoodCoordinate():
+			return nil, nil, &f
+		case <-g.ReceiveScore():
+		case <-deadline:
+			t.Fatal("got nothing from the snake coordinates, game result and food coordinate channels before the test deadline")
+			return nil, nil, nil
+		}
 	}
 }
 
+// testDeadline returns a channel which receives shortly before the deadline of
+// t, set by the go test -timeout flag, so that a test waiting for a value which
+// is never sent fails with a message instead of timing out the whole test run.
+// The channel never receives if t has no deadline. The returned function stops
+// the timer.
+func testDeadline(t testing.TB) (<-chan time.Time, func()) {
+	d, ok := t.(interface{ Deadline() (time.Time, bool) })
+	if !ok {
+		return nil, func() {}
+	}
+	deadline, ok := d.Deadline()
+	if !ok {
+		return nil, func() {}
+	}
+	timer := time.NewTimer(time.Until(deadline) * 9 / 10)
+	return timer.C, func() { timer.Stop() }
+}
+
 // FoodStubValue stores the coordinate and the error
 // returned from FoodStub Generate.
 type FoodStubValue struct {
@@ -94,3 +119,29 @@ func (s *FoodStub) Generate(c []Coordinate) (Coordinate, error) {
 func (s *FoodStub) Seed(c []FoodStubValue) {
 	s.seedValues = c
 }
+
+// maxLeakChecks is how many times VerifyNoGoroutineLeaks yields
+// before reporting the running go routines.
+const maxLeakChecks = 10000
+
+// VerifyNoGoroutineLeaks fails t if the go routines started during the test
+// are still running when the test and its deferred calls have returned.
+// It yields to the scheduler a bounded number of times for the go routines
+// to return, then logs the stacks of all the running go routines. It should
+// be called when the test starts, and the test should not run in parallel
+// with other tests.
+func VerifyNoGoroutineLeaks(t testing.TB) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		for i := 0; runtime.NumGoroutine() > before; i++ {
+			if i == maxLeakChecks {
+				stacks := make([]byte, 1<<20)
+				stacks = stacks[:runtime.Stack(stacks, true)]
+				t.Errorf("got %d go routines, want %d:\n%s", runtime.NumGoroutine(), before, stacks)
+				return
+			}
+			runtime.Gosched()
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package snake
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// MaxPanelWidth is the widest text line, in screen cells, inside a panel.
+const MaxPanelWidth = 60
+
+// PanelBorderColor is the color of the panel borders and titles.
+const PanelBorderColor = tcell.ColorWhite
+
+// WrapText splits s in lines no wider than width screen cells, breaking
+// lines on spaces and on new line characters. Words wider than width are
+// broken on the last rune which fits. The width of each rune is its display
+// width, so East Asian wide characters take two cells.
+func WrapText(s string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		start := len(lines)
+		line, lineWidth := "", 0
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := runewidth.StringWidth(word)
+			if lineWidth > 0 && lineWidth+1+wordWidth <= width {
+				line, lineWidth = line+" "+word, lineWidth+1+wordWidth
+				continue
+			}
+			if lineWidth > 0 {
+				lines = append(lines, line)
+			}
+			for wordWidth > width {
+				head := runewidth.Truncate(word, width, "")
+				if head == "" {
+					// a rune wider than width still takes a line on its own
+					head = string([]rune(word)[:1])
+				}
+				lines = append(lines, head)
+				word = word[len(head):]
+				wordWidth = runewidth.StringWidth(word)
+			}
+			line, lineWidth = word, wordWidth
+		}
+		if line != "" || len(lines) == start {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// panelLine is a line of text inside a panel.
+type panelLine struct {
+	text     string
+	style    tcell.Style
+	centered bool
+}
+
+// panel is a titled box of text lines.
+type panel struct {
+	title string
+	lines []panelLine
+}
+
+// newDialog returns a panel with the word wrapped message.
+func newDialog(title, message string) *panel {
+	return &panel{title, []panelLine{{message, tcell.StyleDefault, true}}}
+}
+
+// drawPanel draws p centered on the screen, over the current screen content.
+// Its lines are word wrapped to fit the screen and MaxPanelWidth, and
+// are cut if they do not fit the screen height.
+func (v *View) drawPanel(p panel) {
+	screenWidth, screenHeight := v.screen.Size()
+	maxWidth := screenWidth - 4
+	if maxWidth > MaxPanelWidth {
+		maxWidth = MaxPanelWidth
+	}
+	var lines []panelLine
+	width := runewidth.StringWidth(p.title) + 2
+	for _, l := range p.lines {
+		for _, text := range WrapText(l.text, maxWidth) {
+			lines = append(lines, panelLine{text, l.style, l.centered})
+			if w := runewidth.StringWidth(text); w > width {
+				width = w
+			}
+		}
+	}
+	if width > maxWidth {
+		width = maxWidth
+	}
+	if len(lines) > screenHeight-2 {
+		lines = lines[:maxInt(screenHeight-2, 0)]
+	}
+	left, top := (screenWidth-width)/2-2, (screenHeight-len(lines))/2-1
+	right, bottom := left+width+3, top+len(lines)+1
+	border := tcell.StyleDefault.Foreground(PanelBorderColor)
+	for x := left + 1; x < right; x++ {
+		v.screen.SetContent(x, top, tcell.RuneHLine, nil, border)
+		v.screen.SetContent(x, bottom, tcell.RuneHLine, nil, border)
+	}
+	for y := top + 1; y < bottom; y++ {
+		v.screen.SetContent(left, y, tcell.RuneVLine, nil, border)
+		v.screen.SetContent(right, y, tcell.RuneVLine, nil, border)
+		for x := left + 1; x < right; x++ {
+			v.screen.SetContent(x, y, ' ', nil, tcell.StyleDefault)
+		}
+	}
+	v.screen.SetContent(left, top, tcell.RuneULCorner, nil, border)
+	v.screen.SetContent(right, top, tcell.RuneURCorner, nil, border)
+	v.screen.SetContent(left, bottom, tcell.RuneLLCorner, nil, border)
+	v.screen.SetContent(right, bottom, tcell.RuneLRCorner, nil, border)
+	if p.title != "" {
+		title := " " + p.title + " "
+		v.drawText(left+(right-left+1-runewidth.StringWidth(title))/2, top, title, border.Bold(true))
+	}
+	for i, l := range lines {
+		x := left + 2
+		if l.centered {
+			x += (width - runewidth.StringWidth(l.text)) / 2
+		}
+		v.drawText(x, top+1+i, l.text, l.style)
+	}
+}
+
+// drawText draws s from the x, y screen cell, advancing
+// by the display width of each rune.
+func (v *View) drawText(x, y int, s string, style tcell.Style) {
+	for _, r := range s {
+		v.screen.SetContent(x, y, r, nil, style)
+		x += runewidth.RuneWidth(r)
+	}
+}
+
+// drawWrappedText draws s word wrapped to the screen width from the top left corner.
+func (v *View) drawWrappedText(s string) {
+	width, _ := v.screen.Size()
+	for y, line := range WrapText(s, width) {
+		v.drawText(0, y, line, tcell.StyleDefault)
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
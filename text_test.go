@@ -0,0 +1,35 @@
+package snake_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestWrapText(t *testing.T) {
+	cases := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"Game lost! Press SPACEBAR", 10, []string{"Game lost!", "Press", "SPACEBAR"}},
+		{"Game lost! Press SPACEBAR", 30, []string{"Game lost! Press SPACEBAR"}},
+		{"first line\nsecond", 20, []string{"first line", "second"}},
+		{"paragraph\n\nafter", 20, []string{"paragraph", "", "after"}},
+		{"breakable", 4, []string{"brea", "kabl", "e"}},
+		{"日本語 テキスト", 6, []string{"日本語", "テキス", "ト"}},
+		{"日本語", 5, []string{"日本", "語"}},
+		{"日", 1, []string{"日"}},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("should wrap %q on %d cells", c.text, c.width), func(t *testing.T) {
+			got := snake.WrapText(c.text, c.width)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got lines %q, want %q", got, c.want)
+			}
+		})
+	}
+}
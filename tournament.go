@@ -0,0 +1,418 @@
+package snake
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const ErrInvalidTournament = SnakeErr("snake: invalid tournament")
+
+// Tournament formats: in a round robin every bot plays every other bot once,
+// in a Swiss system tournament the bots play a number of rounds, each against
+// a bot with close points which they did not play yet.
+const (
+	RoundRobin = "round-robin"
+	Swiss      = "swiss"
+)
+
+// InitialRating is the Elo rating of the bots when a tournament starts,
+// and EloK is the most rating points a bot wins or loses on a game.
+const (
+	InitialRating = 1500
+	EloK          = 32
+)
+
+// DefaultMatchTicks is the number of ticks after which an undecided
+// tournament game is won by the bot with the higher score.
+const DefaultMatchTicks = 2000
+
+// Tournament plays the Bots against each other on headless games of a Width
+// x Height board with the Rules. Each match is played on two games, swapping
+// the snake played by each bot, with the food and the bots random moves
+// seeded from Seed, so the same tournament always has the same results.
+// The games of a round are played concurrently by Workers go routines.
+type Tournament struct {
+	Bots    []Opponent
+	Format  string
+	Rounds  int
+	Width   int
+	Height  int
+	Rules   Rules
+	Ticks   int
+	Seed    int64
+	Workers int
+	Food    func(width, height int, seed int64) FoodGenerator
+}
+
+// NewTournament returns a pointer to a Tournament of the bots in format,
+// seeded with seed, played with the battle royale rules on a 30x20 board by
+// as many go routines as the CPUs. The Swiss system tournaments have enough
+// rounds to rank the bots, which is the base 2 logarithm of their number.
+func NewTournament(bots []Opponent, format string, seed int64) *Tournament {
+	rounds := 0
+	if len(bots) > 1 {
+		rounds = int(math.Ceil(math.Log2(float64(len(bots)))))
+	}
+	return &Tournament{append([]Opponent(nil), bots...), format, rounds, 30, 20, BattleRoyaleRules, DefaultMatchTicks,
+		seed, runtime.NumCPU(), func(width, height int, seed int64) FoodGenerator {
+			return NewSeededFood(width, height, seed)
+		}}
+}
+
+// validate returns ErrInvalidTournament if the tournament has less than two
+// bots, an unknown format, no Swiss rounds, no ticks, no workers or rules
+// with lives, and the error of the first bot which is not a valid opponent.
+func (t *Tournament) validate() error {
+	if len(t.Bots) < 2 || t.Ticks <= 0 || t.Workers <= 0 || t.Food == nil || !t.Rules.valid() || t.Rules.Lives > 0 {
+		return ErrInvalidTournament
+	}
+	switch t.Format {
+	case RoundRobin:
+	case Swiss:
+		if t.Rounds <= 0 {
+			return ErrInvalidTournament
+		}
+	default:
+		return ErrInvalidTournament
+	}
+	for _, b := range t.Bots {
+		if err := b.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match is a pairing of the bots A and B, by index in the tournament bots,
+// played on a Round on games seeded with Seed.
+type Match struct {
+	Round int   `json:"round"`
+	A     int   `json:"a"`
+	B     int   `json:"b"`
+	Seed  int64 `json:"seed"`
+}
+
+// MatchResult is the result of a match: the Points scored by A and B on its
+// games, one for each game won and half for each draw, and the game replays.
+type MatchResult struct {
+	Match
+	Points  [2]float64 `json:"points"`
+	Replays []Replay   `json:"replays"`
+}
+
+// Replay is the record of a tournament game: the bots, the first one playing
+// the snake and the second one the opponent, the food seed, a frame for each
+// tick and the index of the Winner among the bots, or -1 for a draw.
+type Replay struct {
+	Bots   []Opponent    `json:"bots"`
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+	Seed   int64         `json:"seed"`
+	Frames []ReplayFrame `json:"frames"`
+	Winner int           `json:"winner"`
+}
+
+// ReplayFrame is the board of a game after a tick: the snakes of the bots,
+// with the head first and nil if dead, the food and the arena.
+type ReplayFrame struct {
+	Tick   int            `json:"tick"`
+	Snakes [][]Coordinate `json:"snakes"`
+	Food   Coordinate     `json:"food"`
+	Arena  *Bounds        `json:"arena,omitempty"`
+}
+
+// Standing is the rank of a bot in a tournament: its Elo rating, the matches
+// it Won, Drawn and Lost, and its Points, one for each match won or bye and
+// half for each match drawn.
+type Standing struct {
+	Bot    Opponent `json:"bot"`
+	Rating float64  `json:"rating"`
+	Played int      `json:"played"`
+	Won    int      `json:"won"`
+	Drawn  int      `json:"drawn"`
+	Lost   int      `json:"lost"`
+	Byes   int      `json:"byes"`
+	Points float64  `json:"points"`
+}
+
+// Play plays the tournament and returns the standings, ranked by points
+// then by rating, and the match results in the order the matches were
+// paired. The ratings are updated after each round, game by game in the
+// matches order. It returns ErrInvalidTournament if the tournament is
+// not valid, and ErrInvalidOpponents if two snakes do not fit the board.
+func (t *Tournament) Play() ([]Standing, []MatchResult, error) {
+	if err := t.validate(); err != nil {
+		return nil, nil, err
+	}
+	standings := make([]Standing, len(t.Bots))
+	for i, b := range t.Bots {
+		standings[i] = Standing{Bot: b, Rating: InitialRating}
+	}
+	seeds := rand.New(rand.NewSource(t.Seed))
+	played := make(map[[2]int]bool)
+	var results []MatchResult
+	for round := 1; ; round++ {
+		var matches []Match
+		switch {
+		case t.Format == RoundRobin && round == 1:
+			matches = roundRobin(len(t.Bots))
+		case t.Format == Swiss && round <= t.Rounds:
+			var bye int
+			matches, bye = swissPairs(standings, played)
+			if bye >= 0 {
+				standings[bye].Byes++
+				standings[bye].Points++
+			}
+		}
+		if matches == nil {
+			break
+		}
+		for i := range matches {
+			matches[i].Round, matches[i].Seed = round, seeds.Int63()
+			played[[2]int{matches[i].A, matches[i].B}] = true
+			played[[2]int{matches[i].B, matches[i].A}] = true
+		}
+		roundResults, err := t.playMatches(matches)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range roundResults {
+			rate(standings, r)
+		}
+		results = append(results, roundResults...)
+	}
+	ranked := append([]Standing(nil), standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Points != ranked[j].Points {
+			return ranked[i].Points > ranked[j].Points
+		}
+		return ranked[i].Rating > ranked[j].Rating
+	})
+	return ranked, results, nil
+}
+
+// roundRobin returns the matches of every bot against every other bot.
+func roundRobin(bots int) []Match {
+	var matches []Match
+	for a := 0; a < bots; a++ {
+		for b := a + 1; b < bots; b++ {
+			matches = append(matches, Match{A: a, B: b})
+		}
+	}
+	return matches
+}
+
+// swissPairs pairs the bots ranked by points then by rating, each with the
+// next unpaired bot which it did not play yet, or with the next unpaired bot
+// if it played all of them. With an odd number of bots the last ranked bot
+// which had no bye yet gets a bye, whose index is returned, or -1 if none.
+func swissPairs(standings []Standing, played map[[2]int]bool) ([]Match, int) {
+	order := make([]int, len(standings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := standings[order[i]], standings[order[j]]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		return a.Rating > b.Rating
+	})
+	bye := -1
+	if len(order)%2 == 1 {
+		i := len(order) - 1
+		for i > 0 && standings[order[i]].Byes > 0 {
+			i--
+		}
+		bye = order[i]
+		order = append(order[:i:i], order[i+1:]...)
+	}
+	var matches []Match
+	paired := make([]bool, len(order))
+	for i := range order {
+		if paired[i] {
+			continue
+		}
+		opponent := -1
+		for j := i + 1; j < len(order); j++ {
+			if paired[j] {
+				continue
+			}
+			if opponent < 0 {
+				opponent = j
+			}
+			if !played[[2]int{order[i], order[j]}] {
+				opponent = j
+				break
+			}
+		}
+		paired[i], paired[opponent] = true, true
+		matches = append(matches, Match{A: order[i], B: order[opponent]})
+	}
+	return matches, bye
+}
+
+// playMatches plays the matches concurrently on the tournament workers,
+// returning their results in the matches order, or the first error.
+func (t *Tournament) playMatches(matches []Match) ([]MatchResult, error) {
+	results := make([]MatchResult, len(matches))
+	errs := make([]error, len(matches))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < t.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = t.playMatch(matches[i])
+			}
+		}()
+	}
+	for i := range matches {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// playMatch plays the match on two games with the same seeds, the bot A
+// playing the snake on the first game and the opponent on the second one.
+func (t *Tournament) playMatch(m Match) (MatchResult, error) {
+	seeds := rand.New(rand.NewSource(m.Seed))
+	food, a, b := seeds.Int63(), t.Bots[m.A], t.Bots[m.B]
+	a.Seed, b.Seed = seeds.Int63(), seeds.Int63()
+	result := MatchResult{Match: m}
+	for i, bots := range [][]Opponent{{a, b}, {b, a}} {
+		replay, err := t.playGame(bots, food)
+		if err != nil {
+			return MatchResult{}, err
+		}
+		switch winner := replay.Winner; {
+		case winner < 0:
+			result.Points[0] += 0.5
+			result.Points[1] += 0.5
+		case winner == i:
+			result.Points[0]++
+		default:
+			result.Points[1]++
+		}
+		result.Replays = append(result.Replays, replay)
+	}
+	return result, nil
+}
+
+// playGame plays a headless game on which the first bot drives the snake
+// and the second bot is the opponent, until the game is over, the opponent
+// dies or the tournament ticks run out.
+func (t *Tournament) playGame(bots []Opponent, seed int64) (Replay, error) {
+	s := NewSnake(t.Width, t.Height)
+	g := NewGame(s, RealClock{}, t.Food(t.Width, t.Height, seed))
+	defer g.Quit()
+	g.SetRules(t.Rules)
+	if err := g.SetOpponents(bots[1:]); err != nil {
+		return Replay{}, err
+	}
+	rng := rand.New(rand.NewSource(bots[0].Seed))
+	player := &rival{Opponent: bots[0], snake: s, strategy: Strategies[bots[0].Strategy](rng), rng: rng, direction: s.Face(), alive: true}
+	replay := Replay{Bots: bots, Width: t.Width, Height: t.Height, Seed: seed}
+	if err := g.startHeadless(); err != nil {
+		return Replay{}, err
+	}
+	var result *bool
+	state := g.Save()
+	for tick := 0; tick < t.Ticks && result == nil && state.Opponents[0].Alive; tick++ {
+		result = g.stepBot(player)
+		state = g.Save()
+		replay.Frames = append(replay.Frames, frame(state, result))
+	}
+	replay.Winner = gameWinner(state, result)
+	return replay, nil
+}
+
+// frame returns the replay frame of the game state, in which
+// the snake is dead if the game was lost.
+func frame(s SaveState, result *bool) ReplayFrame {
+	f := ReplayFrame{Tick: s.Tick, Food: s.Food, Arena: s.Arena}
+	if result == nil || *result {
+		f.Snakes = append(f.Snakes, s.Snake)
+	} else {
+		f.Snakes = append(f.Snakes, nil)
+	}
+	for _, o := range s.Opponents {
+		f.Snakes = append(f.Snakes, o.Snake)
+	}
+	return f
+}
+
+// gameWinner returns the index of the bot which won the game in state s
+// with result, 0 for the snake and 1 for the opponent, or -1 for a draw.
+// A game lost by the snake is won by the opponent if it is alive and is
+// drawn otherwise, while an undecided game with both snakes alive is won
+// by the higher score.
+func gameWinner(s SaveState, result *bool) int {
+	opponent := s.Opponents[0]
+	lost := result != nil && !*result
+	switch {
+	case lost && opponent.Alive:
+		return 1
+	case lost:
+		return -1
+	case !opponent.Alive:
+		return 0
+	case s.Score > opponent.Score:
+		return 0
+	case s.Score < opponent.Score:
+		return 1
+	}
+	return -1
+}
+
+// rate updates the standings of the bots of the match result,
+// rating each of its games in order.
+func rate(standings []Standing, r MatchResult) {
+	a, b := &standings[r.A], &standings[r.B]
+	for i, replay := range r.Replays {
+		score := 0.5
+		if replay.Winner >= 0 {
+			score = 0
+			if replay.Winner == i {
+				score = 1
+			}
+		}
+		delta := EloK * (score - expectedScore(a.Rating, b.Rating))
+		a.Rating += delta
+		b.Rating -= delta
+	}
+	a.Played++
+	b.Played++
+	switch {
+	case r.Points[0] > r.Points[1]:
+		a.Won++
+		b.Lost++
+		a.Points++
+	case r.Points[0] < r.Points[1]:
+		b.Won++
+		a.Lost++
+		b.Points++
+	default:
+		a.Drawn++
+		b.Drawn++
+		a.Points += 0.5
+		b.Points += 0.5
+	}
+}
+
+// expectedScore returns the expected score of a bot rated a
+// playing a bot rated b, from 0 for a loss to 1 for a win.
+func expectedScore(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
@@ -0,0 +1,105 @@
+package snake_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestTournament(t *testing.T) {
+	bots := []snake.Opponent{
+		{Name: "Random", Strategy: "random"},
+		{Name: "Greedy", Strategy: "greedy", Difficulty: snake.Hard},
+		{Name: "Cautious", Strategy: "cautious", Difficulty: snake.Hard},
+		{Name: "Clumsy", Strategy: "cautious", Difficulty: snake.Easy},
+	}
+
+	t.Run("round robin should pair every bot with every other bot", func(t *testing.T) {
+		standings, results, err := snake.NewTournament(bots[:3], snake.RoundRobin, 1).Play()
+		snake.AssertNoError(t, err)
+		if len(results) != 3 {
+			t.Fatalf("got %d matches, want 3", len(results))
+		}
+		for _, r := range results {
+			if len(r.Replays) != 2 || r.Points[0]+r.Points[1] != 2 {
+				t.Errorf("got match %+v with %d games, want 2 games worth 2 points", r.Match, len(r.Replays))
+			}
+			if r.Replays[0].Bots[0].Name != r.Replays[1].Bots[1].Name {
+				t.Errorf("got bots %v and %v, want the sides swapped on the second game", r.Replays[0].Bots, r.Replays[1].Bots)
+			}
+		}
+		assertRatingsSum(t, standings, 3*snake.InitialRating)
+		for i := 1; i < len(standings); i++ {
+			if standings[i].Points > standings[i-1].Points {
+				t.Errorf("got standings %+v, want them ranked by points", standings)
+			}
+		}
+	})
+
+	t.Run("should play the same tournament with the same seed", func(t *testing.T) {
+		tournament := snake.NewTournament(bots, snake.RoundRobin, 7)
+		standings, results, err := tournament.Play()
+		snake.AssertNoError(t, err)
+		tournament.Workers = 1
+		sequential, sequentialResults, err := tournament.Play()
+		snake.AssertNoError(t, err)
+		if !reflect.DeepEqual(standings, sequential) || !reflect.DeepEqual(results, sequentialResults) {
+			t.Error("got different results playing the same tournament sequentially")
+		}
+	})
+
+	t.Run("swiss system should not pair the bots twice", func(t *testing.T) {
+		tournament := snake.NewTournament(bots, snake.Swiss, 3)
+		standings, results, err := tournament.Play()
+		snake.AssertNoError(t, err)
+		if tournament.Rounds != 2 || len(results) != 4 {
+			t.Fatalf("got %d matches in %d rounds, want 4 matches in 2 rounds", len(results), tournament.Rounds)
+		}
+		pairs := make(map[[2]int]bool)
+		for _, r := range results {
+			pair := [2]int{r.A, r.B}
+			if r.A > r.B {
+				pair = [2]int{r.B, r.A}
+			}
+			if pairs[pair] {
+				t.Errorf("got bots %d and %d paired twice", r.A, r.B)
+			}
+			pairs[pair] = true
+		}
+		assertRatingsSum(t, standings, 4*snake.InitialRating)
+	})
+
+	t.Run("swiss system should give a bye to a different bot each round", func(t *testing.T) {
+		tournament := snake.NewTournament(bots[:3], snake.Swiss, 5)
+		tournament.Rounds = 3
+		standings, _, err := tournament.Play()
+		snake.AssertNoError(t, err)
+		for _, s := range standings {
+			if s.Byes != 1 || s.Played != 2 {
+				t.Errorf("got %+v, want one bye and two matches played", s)
+			}
+		}
+	})
+
+	t.Run("should not play invalid tournaments", func(t *testing.T) {
+		_, _, err := snake.NewTournament(bots[:1], snake.RoundRobin, 1).Play()
+		snake.AssertError(t, err, snake.ErrInvalidTournament)
+		_, _, err = snake.NewTournament(bots, "knockout", 1).Play()
+		snake.AssertError(t, err, snake.ErrInvalidTournament)
+		_, _, err = snake.NewTournament([]snake.Opponent{bots[0], {Name: "Unknown", Strategy: "unknown"}}, snake.RoundRobin, 1).Play()
+		snake.AssertError(t, err, snake.ErrUnknownStrategy)
+	})
+}
+
+func assertRatingsSum(t testing.TB, standings []snake.Standing, want float64) {
+	t.Helper()
+	sum := 0.0
+	for _, s := range standings {
+		sum += s.Rating
+	}
+	if math.Abs(sum-want) > 1e-6 {
+		t.Errorf("got ratings summing to %f, want %f", sum, want)
+	}
+}
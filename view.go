@@ -1,15 +1,24 @@
 package snake
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 const BodyRune = '▮'
+const HeadForegroundColor = tcell.ColorYellow
 const BodyForegroundColor = tcell.ColorWhite
 const BodyBackgroundColor = tcell.ColorGray
 const FoodRune = '◆'
 const FoodForegroundColor = tcell.ColorRed
 const FoodBackgroundColor = tcell.ColorBlack
+const WinTitle = "You won"
 const WinMessage = "Game won! Press SPACEBAR to start a new game or press Q to quit..."
+const LoseTitle = "Game over"
 const LoseMessage = "Game lost! Press SPACEBAR to start a new game or press Q to quit..."
+const TooSmallMessage = "Terminal too small, resize it to at least %dx%d..."
 
 // ViewHandler interface defines how a view should handle
 // screen refresh and how should expose snake's change direction input.
@@ -37,62 +46,333 @@ type View struct {
 	directionC  chan Direction
 	eventsC     chan tcell.Event
 	quitEventsC chan struct{}
+	pollDoneC   chan struct{}
 	newGameC    chan struct{}
 	quitGameC   chan struct{}
+	selectC     chan struct{}
+	pauseC      chan struct{}
+	saveC       chan struct{}
+	glyphs      Glyphs
+	renderer    Renderer
+	resizeC     chan Size
+	boardWidth  int
+	boardHeight int
+	camera      Coordinate
+	deadZone    int
+	status      *Status
+	snake       *[]Coordinate
+	face        Direction
+	food        *Coordinate
+	portals     []Portal
+	entities    []Entity
+	blinking    int
+	opponents   []OpponentState
+	arena       Bounds
+	dialog      *panel
+	menu        *Menu
+	releaseOnce sync.Once
+	mu          sync.Mutex
 }
 
 // NewView returns a View struct pointer setting the screen,
 // starting the screen events loop channel in a go routine
 // and starts polling the screen events channel for directions
 // in another go routine.
+//
+// The board is drawn with a TextRenderer inside a frame centered
+// on the screen, above a status bar. If the board does not fit the
+// screen, the frame holds a viewport which follows the snake head
+// and a minimap of the whole board. The snake is drawn with
+// UnicodeGlyphs, falling back to ASCIIGlyphs if the screen can not
+// display them.
 func NewView(screen tcell.Screen) *View {
 	directionChannel := make(chan Direction)
 	eventsChannel := make(chan tcell.Event)
 	quitEventsChannel := make(chan struct{})
 	newGameChannel := make(chan struct{})
 	quitGameChannel := make(chan struct{})
+	selectChannel := make(chan struct{})
+	pauseChannel := make(chan struct{})
+	saveChannel := make(chan struct{})
+	resizeChannel := make(chan Size, 1)
 	go screen.ChannelEvents(eventsChannel, quitEventsChannel)
 	view := &View{
-		screen,
-		directionChannel,
-		eventsChannel,
-		quitEventsChannel,
-		newGameChannel,
-		quitGameChannel,
+		screen:      screen,
+		directionC:  directionChannel,
+		eventsC:     eventsChannel,
+		quitEventsC: quitEventsChannel,
+		pollDoneC:   make(chan struct{}),
+		newGameC:    newGameChannel,
+		quitGameC:   quitGameChannel,
+		selectC:     selectChannel,
+		pauseC:      pauseChannel,
+		saveC:       saveChannel,
+		glyphs:      UnicodeGlyphs,
+		renderer:    TextRenderer{},
+		resizeC:     resizeChannel,
+		deadZone:    DefaultDeadZone,
+	}
+	if !UnicodeGlyphs.canDisplay(screen) {
+		view.glyphs = ASCIIGlyphs
 	}
 	go view.pollKeys()
 	return view
 }
 
-// Refresh clears the screen, then prints the snake body on
-// the snake coordinates and the food on the food coordinates.
+// SetGlyphs sets the runes used to draw the snake.
+func (v *View) SetGlyphs(g Glyphs) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.glyphs = g
+}
+
+// SetRenderer sets the renderer which draws the board cells on the screen.
+func (v *View) SetRenderer(r Renderer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.renderer = r
+}
+
+// BoardSize returns the board width and height which fit in the screen
+// inside the frame with the view renderer.
+func (v *View) BoardSize() (int, int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.fitBoard()
+}
+
+// SetBoardSize sets the board width and height drawn inside the frame.
+// If it is never called the view draws a board of BoardSize.
+// If the board is larger than BoardSize the view draws a viewport of the board.
+func (v *View) SetBoardSize(width, height int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.boardWidth, v.boardHeight = width, height
+}
+
+// SetFace sets the snake face direction, towards which its head is drawn.
+func (v *View) SetFace(d Direction) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.face = d
+}
+
+// SetPortals sets the board portals, drawing each pair with the same color
+// of PortalColors. The portals which do not fit the board are not drawn.
+func (v *View) SetPortals(portals []Portal) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.portals = append([]Portal{}, portals...)
+}
+
+// SetEntities sets the entities drawn on the board.
+func (v *View) SetEntities(entities []Entity) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.entities = copyEntities(entities)
+}
+
+// SetInvulnerable sets the ticks left during which the snake is
+// invulnerable: while they are left the snake blinks, drawing
+// only its head on the odd ticks.
+func (v *View) SetInvulnerable(ticks int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.blinking = ticks
+}
+
+// SetOpponents sets the opponent snakes drawn on the board: the i-th
+// opponent is drawn with OpponentColors[i%len(OpponentColors)], and
+// the dead opponents are not drawn.
+func (v *View) SetOpponents(opponents []OpponentState) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.opponents = append([]OpponentState(nil), opponents...)
+}
+
+// SetArena sets the arena bounds: the board cells outside them are drawn
+// as walls, with WallRune and WallColor. The zero Bounds draw no walls.
+func (v *View) SetArena(b Bounds) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.arena = b
+}
+
+// SetDeadZone sets how many cells the snake head can get close to
+// the viewport sides before the viewport scrolls.
+func (v *View) SetDeadZone(cells int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.deadZone = cells
+}
+
+// ToScreen returns the screen cell on which the board coordinate c is drawn.
+func (v *View) ToScreen(c Coordinate) (int, int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.toScreen(c)
+}
+
+// Refresh clears the screen, then prints the snake on
+// the snake coordinates and the food on the food coordinates
+// inside the board frame, and the last status below the frame.
+// The snake head, body and tail are drawn with the view glyphs.
 // The snake body will be printed overwriting the food, if their coordinates overlap.
 // It will not print the respective coordinates if the snake or the food coordinates are nil.
+// If the board frame does not fit the screen it displays a terminal too small message.
 func (v *View) Refresh(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate) {
 	if snakeCoordinates == nil && foodCoordinate == nil {
 		return
 	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.snake, v.food, v.dialog, v.menu = snakeCoordinates, foodCoordinate, nil, nil
+	v.draw()
+}
+
+// RefreshFrame displays the snake and the food like Refresh and s on the
+// status bar like RefreshStatus, showing the screen once.
+func (v *View) RefreshFrame(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate, s Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.status = &s
+	if snakeCoordinates == nil && foodCoordinate == nil {
+		if v.dialog == nil && v.menu == nil && v.fits() {
+			v.drawStatus()
+			v.screen.Show()
+		}
+		return
+	}
+	v.snake, v.food, v.dialog, v.menu = snakeCoordinates, foodCoordinate, nil, nil
+	v.draw()
+}
+
+// refreshFrame displays the snake and the food coordinates with the status
+// s on view, in a single screen update if view implements a
+// RefreshFrame(*[]Coordinate, *Coordinate, Status) method. The status is
+// displayed only if view implements a RefreshStatus(Status) method.
+func refreshFrame(view ViewHandler, snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate, s Status) {
+	if v, ok := view.(frameView); ok {
+		v.RefreshFrame(snakeCoordinates, foodCoordinate, s)
+		return
+	}
+	view.Refresh(snakeCoordinates, foodCoordinate)
+	if v, ok := view.(statusView); ok {
+		v.RefreshStatus(s)
+	}
+}
+
+// RefreshStatus displays s on the status bar below the board frame.
+func (v *View) RefreshStatus(s Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.status = &s
+	if v.dialog != nil || v.menu != nil || !v.fits() {
+		return
+	}
+	v.drawStatus()
+	v.screen.Show()
+}
+
+// draw draws the board and the status, then the last menu or dialog
+// over the frozen board. If the board frame does not fit the screen and
+// there is no menu nor dialog, it draws a terminal too small message.
+func (v *View) draw() {
 	v.screen.Clear()
-	if foodCoordinate != nil {
+	fits := v.fits()
+	if fits && (v.snake != nil || v.food != nil) {
+		v.drawBoard()
+	}
+	switch {
+	case v.menu != nil:
+		v.drawPanel(v.menu.panel())
+	case v.dialog != nil:
+		v.drawPanel(*v.dialog)
+	case !fits:
+		cols, rows := v.minFrameSize()
+		v.drawWrappedText(fmt.Sprintf(TooSmallMessage, cols, rows))
+	}
+	v.screen.Show()
+}
+
+func (v *View) drawBoard() {
+	if v.snake != nil && len(*v.snake) > 0 {
+		v.follow((*v.snake)[0])
+	}
+	v.drawFrame()
+	cells := newCells(v.viewport())
+	boardWidth, boardHeight := v.board()
+	if v.arena != (Bounds{}) {
+		for _, c := range v.arena.outside(boardWidth, boardHeight) {
+			v.setCell(cells, c, Cell{WallRune, tcell.StyleDefault.Foreground(WallColor), WallColor})
+		}
+	}
+	for i, p := range v.portals {
+		if validatePortals([]Portal{p}, boardWidth, boardHeight, nil) != nil {
+			continue
+		}
+		color := PortalColors[i%len(PortalColors)]
+		cell := Cell{PortalRune, tcell.StyleDefault.Foreground(color), color}
+		v.setCell(cells, p.A, cell)
+		v.setCell(cells, p.B, cell)
+	}
+	for _, e := range v.entities {
+		if r, color, ok := entityGlyph(e.Kind); ok {
+			v.setCell(cells, e.Position, Cell{r, tcell.StyleDefault.Foreground(color), color})
+		}
+	}
+	if v.food != nil {
 		foodStyle := tcell.StyleDefault.Foreground(FoodForegroundColor).Background(FoodBackgroundColor)
-		v.screen.SetContent(foodCoordinate.X, foodCoordinate.Y, FoodRune, nil, foodStyle)
+		v.setCell(cells, *v.food, Cell{FoodRune, foodStyle, FoodForegroundColor})
+	}
+	for i, o := range v.opponents {
+		if !o.Alive {
+			continue
+		}
+		color := OpponentColors[i%len(OpponentColors)]
+		style := tcell.StyleDefault.Foreground(color).Background(BodyBackgroundColor)
+		for j := len(o.Snake) - 1; j >= 0; j-- {
+			v.setCell(cells, o.Snake[j], Cell{v.glyphs.Segment(o.Snake, j, o.Face, v.portals...), style, color})
+		}
 	}
-	if snakeCoordinates != nil {
+	if v.snake != nil {
 		snakeStyle := tcell.StyleDefault.Foreground(BodyForegroundColor).Background(BodyBackgroundColor)
-		for _, c := range *snakeCoordinates {
-			v.screen.SetContent(c.X, c.Y, BodyRune, nil, snakeStyle)
+		headStyle := snakeStyle.Foreground(HeadForegroundColor)
+		tail := len(*v.snake) - 1
+		if v.blinking%2 == 1 {
+			tail = 0
+		}
+		for i := tail; i >= 0; i-- {
+			cell := Cell{v.glyphs.Segment(*v.snake, i, v.face, v.portals...), snakeStyle, BodyForegroundColor}
+			if i == 0 {
+				cell.Style, cell.Color = headStyle, HeadForegroundColor
+			}
+			v.setCell(cells, (*v.snake)[i], cell)
 		}
 	}
-	v.screen.Show()
+	originX, originY := v.origin()
+	v.renderer.Draw(v.screen, originX, originY, cells)
+	v.drawMinimap()
+	v.drawStatus()
 }
 
-// Release releases the underlying screen resources.
+// Release stops polling the screen events, closes the input channels,
+// then releases the underlying screen resources, restoring the terminal.
+// It can be called more than once.
 func (v *View) Release() {
-	// Screen.ChannelEvents will close v.eventsC after we close v.quitEventsC
-	close(v.quitEventsC)
-	close(v.directionC)
-	close(v.newGameC)
-	v.screen.Fini()
+	v.releaseOnce.Do(func() {
+		// Screen.ChannelEvents will close v.eventsC after we close v.quitEventsC,
+		// then pollKeys returns and does not send on the input channels anymore
+		close(v.quitEventsC)
+		<-v.pollDoneC
+		close(v.directionC)
+		close(v.newGameC)
+		close(v.quitGameC)
+		close(v.selectC)
+		close(v.pauseC)
+		close(v.saveC)
+		v.screen.Fini()
+	})
 }
 
 // ReceiveDirection returns a Direction receiver channel
@@ -102,14 +382,40 @@ func (v *View) ReceiveDirection() <-chan Direction {
 	return v.directionC
 }
 
-// DisplayWin clears the screen and displays a win message.
+// DisplayWin displays a win dialog over the last board.
 func (v *View) DisplayWin() {
-	v.printMessage(WinMessage)
+	v.displayDialog(WinTitle, WinMessage)
 }
 
-// DisplayLose clears the screen and displays a lose message.
+// DisplayLose displays a lose dialog over the last board.
 func (v *View) DisplayLose() {
-	v.printMessage(LoseMessage)
+	v.displayDialog(LoseTitle, LoseMessage)
+}
+
+// ReceiveSelectSignal returns an empty struct receiver channel
+// which will signal when the user presses ENTER to select a menu item.
+func (v *View) ReceiveSelectSignal() <-chan struct{} {
+	return v.selectC
+}
+
+// ReceivePauseSignal returns an empty struct receiver channel
+// which will signal when the user presses P or ESC to pause the game
+// or to go back from a menu.
+func (v *View) ReceivePauseSignal() <-chan struct{} {
+	return v.pauseC
+}
+
+// ReceiveSaveSignal returns an empty struct receiver channel
+// which will signal when the user presses S to save the game.
+func (v *View) ReceiveSaveSignal() <-chan struct{} {
+	return v.saveC
+}
+
+// ReceiveResize returns a Size receiver channel which will be fed with
+// the board size fitting the screen when the terminal is resized.
+// Only the last size is kept if the receiver does not keep up with the resizes.
+func (v *View) ReceiveResize() <-chan Size {
+	return v.resizeC
 }
 
 // ReceiveNewGameSignal returns an empty struct receiver channel
@@ -119,46 +425,84 @@ func (v *View) ReceiveNewGameSignal() <-chan struct{} {
 }
 
 // ReceiveQuitSignal returns an empty struct receiver channel
-// which will signal when the user presses the Q button to request to exit from the game.
+// which will signal when the user presses the Q button or CTRL+C to request to exit from the game.
 func (v *View) ReceiveQuitSignal() <-chan struct{} {
 	return v.quitGameC
 }
 
 func (v *View) pollKeys() {
+	defer close(v.pollDoneC)
 	for e := range v.eventsC {
+		if _, ok := e.(*tcell.EventResize); ok {
+			v.resize()
+			continue
+		}
 		if keyEvent, ok := e.(*tcell.EventKey); ok {
 			switch keyEvent.Key() {
 			case tcell.KeyUp:
-				v.directionC <- Up
+				v.sendDirection(Up)
 			case tcell.KeyDown:
-				v.directionC <- Down
+				v.sendDirection(Down)
 			case tcell.KeyRight:
-				v.directionC <- Right
+				v.sendDirection(Right)
 			case tcell.KeyLeft:
-				v.directionC <- Left
+				v.sendDirection(Left)
+			case tcell.KeyEnter:
+				v.signal(v.selectC)
+			case tcell.KeyEscape:
+				v.signal(v.pauseC)
+			case tcell.KeyCtrlC:
+				v.signal(v.quitGameC)
 			case tcell.KeyRune:
 				switch keyEvent.Rune() {
 				case ' ':
-					v.newGameC <- struct{}{}
+					v.signal(v.newGameC)
 				case 'q', 'Q':
-					v.quitGameC <- struct{}{}
+					v.signal(v.quitGameC)
+				case 'p', 'P':
+					v.signal(v.pauseC)
+				case 's', 'S':
+					v.signal(v.saveC)
 				}
 			}
 		}
 	}
 }
 
-func (v *View) printMessage(message string) {
-	v.screen.Clear()
-	width, _ := v.screen.Size()
-	x, y := 0, 0
-	for _, c := range message {
-		if x >= width {
-			x = 0
-			y++
-		}
-		v.screen.SetContent(x, y, c, nil, tcell.StyleDefault)
-		x++
+// sendDirection sends d on the direction channel unless the view is released.
+func (v *View) sendDirection(d Direction) {
+	select {
+	case v.directionC <- d:
+	case <-v.quitEventsC:
 	}
-	v.screen.Show()
+}
+
+// signal sends on c unless the view is released.
+func (v *View) signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	case <-v.quitEventsC:
+	}
+}
+
+// resize redraws the screen after a terminal resize and sends
+// the board size which now fits the screen on the resize channel.
+func (v *View) resize() {
+	v.mu.Lock()
+	v.screen.Sync()
+	v.draw()
+	width, height := v.fitBoard()
+	v.mu.Unlock()
+	select {
+	case <-v.resizeC:
+	default:
+	}
+	v.resizeC <- Size{width, height}
+}
+
+func (v *View) displayDialog(title, message string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.dialog, v.menu = newDialog(title, message), nil
+	v.draw()
 }
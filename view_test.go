@@ -2,8 +2,11 @@ package snake_test
 
 import (
 	"fmt"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/castagnadaniele/go-snake"
 	"github.com/gdamore/tcell/v2"
@@ -20,14 +23,16 @@ func TestView(t *testing.T) {
 
 		view.Refresh(snakeCoordinates, foodCoordinate)
 
+		wantRunes := []rune{'◀', '━', '╸'}
+		wantForegrounds := []tcell.Color{snake.HeadForegroundColor, snake.BodyForegroundColor, snake.BodyForegroundColor}
 		for i := 0; i < 3; i++ {
-			r, _, s, _ := screen.GetContent(i, 0)
-			assertCellRune(t, i, 0, r, snake.BodyRune)
+			r, _, s, _ := getBoardContent(view, screen, snake.Coordinate{i, 0})
+			assertCellRune(t, i, 0, r, wantRunes[i])
 			fg, bg, _ := s.Decompose()
-			assertForegroundColor(t, i, 0, fg, snake.BodyForegroundColor)
+			assertForegroundColor(t, i, 0, fg, wantForegrounds[i])
 			assertBackgroundColor(t, i, 0, bg, snake.BodyBackgroundColor)
 		}
-		r, _, s, _ := screen.GetContent(foodCoordinate.X, foodCoordinate.Y)
+		r, _, s, _ := getBoardContent(view, screen, *foodCoordinate)
 		assertCellRune(t, foodCoordinate.X, foodCoordinate.Y, r, snake.FoodRune)
 		fg, bg, _ := s.Decompose()
 		assertForegroundColor(t, foodCoordinate.X, foodCoordinate.Y, fg, snake.FoodForegroundColor)
@@ -41,13 +46,333 @@ func TestView(t *testing.T) {
 
 		view.Refresh(snakeCoordinates, foodCoordinate)
 
-		r, _, s, _ := screen.GetContent(0, 0)
-		assertCellRune(t, 0, 0, r, snake.BodyRune)
+		r, _, s, _ := getBoardContent(view, screen, snake.Coordinate{0, 0})
+		assertCellRune(t, 0, 0, r, '◀')
 		fg, bg, _ := s.Decompose()
-		assertForegroundColor(t, 0, 0, fg, snake.BodyForegroundColor)
+		assertForegroundColor(t, 0, 0, fg, snake.HeadForegroundColor)
 		assertBackgroundColor(t, 0, 0, bg, snake.BodyBackgroundColor)
 	})
 
+	headTestCases := []struct {
+		coordinates []snake.Coordinate
+		want        rune
+	}{
+		{[]snake.Coordinate{{5, 4}, {5, 5}, {5, 6}}, '▲'},
+		{[]snake.Coordinate{{5, 6}, {5, 5}, {5, 4}}, '▼'},
+		{[]snake.Coordinate{{4, 5}, {5, 5}, {6, 5}}, '◀'},
+		{[]snake.Coordinate{{6, 5}, {5, 5}, {4, 5}}, '▶'},
+	}
+
+	for _, tc := range headTestCases {
+		t.Run(fmt.Sprintf("should display head %c for snake %v", tc.want, tc.coordinates), func(t *testing.T) {
+			view, screen := initView(t, width, height)
+			defer view.Release()
+
+			view.Refresh(&tc.coordinates, nil)
+
+			head := tc.coordinates[0]
+			r, _, _, _ := getBoardContent(view, screen, head)
+			assertCellRune(t, head.X, head.Y, r, tc.want)
+		})
+	}
+
+	t.Run("should display corners and tail", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		coordinates := &[]snake.Coordinate{{1, 0}, {0, 0}, {0, 1}, {0, 2}, {1, 2}, {2, 2}, {2, 1}}
+		wantRunes := []rune{'▶', '┏', '┃', '┗', '━', '┛', '╻'}
+
+		view.Refresh(coordinates, nil)
+
+		for i, c := range *coordinates {
+			r, _, _, _ := getBoardContent(view, screen, c)
+			assertCellRune(t, c.X, c.Y, r, wantRunes[i])
+		}
+	})
+
+	t.Run("should display portals and the snake threading through them", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		portals := []snake.Portal{{A: snake.Coordinate{X: 10, Y: 5}, B: snake.Coordinate{X: 20, Y: 8}}}
+		coordinates := &[]snake.Coordinate{{19, 7}, {19, 8}, {11, 5}, {12, 5}}
+		wantRunes := []rune{'▲', '┗', '━', '╸'}
+
+		view.SetPortals(portals)
+		view.Refresh(coordinates, nil)
+
+		for i, c := range *coordinates {
+			r, _, _, _ := getBoardContent(view, screen, c)
+			assertCellRune(t, c.X, c.Y, r, wantRunes[i])
+		}
+		for _, c := range []snake.Coordinate{portals[0].A, portals[0].B} {
+			r, _, s, _ := getBoardContent(view, screen, c)
+			assertCellRune(t, c.X, c.Y, r, snake.PortalRune)
+			fg, _, _ := s.Decompose()
+			assertForegroundColor(t, c.X, c.Y, fg, snake.PortalColors[0])
+		}
+	})
+
+	t.Run("should display entities", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		entities := []snake.Entity{
+			{Kind: snake.Block, Position: snake.Coordinate{10, 10}, Path: []snake.Coordinate{{10, 10}}},
+			{Kind: snake.Mouse, Position: snake.Coordinate{11, 10}},
+			{Kind: snake.Hazard, Position: snake.Coordinate{12, 10}},
+		}
+		wantRunes := []rune{snake.BlockRune, snake.MouseRune, snake.HazardRune}
+		wantColors := []tcell.Color{snake.BlockColor, snake.MouseColor, snake.HazardColor}
+
+		view.SetEntities(entities)
+		view.Refresh(snakeCoordinates, nil)
+
+		for i, e := range entities {
+			r, _, s, _ := getBoardContent(view, screen, e.Position)
+			assertCellRune(t, e.Position.X, e.Position.Y, r, wantRunes[i])
+			fg, _, _ := s.Decompose()
+			assertForegroundColor(t, e.Position.X, e.Position.Y, fg, wantColors[i])
+		}
+	})
+
+	t.Run("should display the alive opponents in their colors", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		opponents := []snake.OpponentState{
+			{Snake: []snake.Coordinate{{10, 10}, {11, 10}, {12, 10}}, Face: snake.Left, Alive: true},
+			{Alive: false},
+			{Snake: []snake.Coordinate{{10, 12}, {11, 12}, {12, 12}}, Face: snake.Left, Alive: true},
+		}
+		wantRunes := []rune{'◀', '━', '╸'}
+
+		view.SetOpponents(opponents)
+		view.Refresh(snakeCoordinates, nil)
+
+		for _, i := range []int{0, 2} {
+			for j, c := range opponents[i].Snake {
+				r, _, s, _ := getBoardContent(view, screen, c)
+				assertCellRune(t, c.X, c.Y, r, wantRunes[j])
+				fg, _, _ := s.Decompose()
+				assertForegroundColor(t, c.X, c.Y, fg, snake.OpponentColors[i])
+			}
+		}
+	})
+
+	t.Run("should show each frame once", func(t *testing.T) {
+		screen := &showCounter{SimulationScreen: tcell.NewSimulationScreen("UTF-8")}
+		snake.AssertNoError(t, screen.Init())
+		screen.SetSize(width, height)
+		view := snake.NewView(screen)
+		defer view.Release()
+
+		view.RefreshFrame(snakeCoordinates, &snake.Coordinate{6, 6}, snake.Status{Score: 3})
+
+		if screen.shows != 1 {
+			t.Errorf("got the screen shown %d times, want once", screen.shows)
+		}
+		r, _, _, _ := getBoardContent(view, screen, snake.Coordinate{6, 6})
+		assertCellRune(t, 6, 6, r, snake.FoodRune)
+	})
+
+	t.Run("should draw the head towards the snake face", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+
+		view.SetFace(snake.Up)
+		view.Refresh(snakeCoordinates, nil)
+
+		r, _, _, _ := getBoardContent(view, screen, (*snakeCoordinates)[0])
+		assertCellRune(t, 0, 0, r, '▲')
+	})
+
+	t.Run("should display the walls outside the arena", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		view.SetBoardSize(20, 10)
+
+		view.SetArena(snake.Bounds{Min: snake.Coordinate{1, 1}, Max: snake.Coordinate{18, 8}})
+		view.Refresh(&[]snake.Coordinate{{5, 5}, {6, 5}, {7, 5}}, nil)
+
+		for _, c := range []snake.Coordinate{{0, 0}, {19, 9}, {10, 0}, {0, 5}} {
+			r, _, s, _ := getBoardContent(view, screen, c)
+			assertCellRune(t, c.X, c.Y, r, snake.WallRune)
+			fg, _, _ := s.Decompose()
+			assertForegroundColor(t, c.X, c.Y, fg, snake.WallColor)
+		}
+		if r, _, _, _ := getBoardContent(view, screen, snake.Coordinate{10, 3}); r == snake.WallRune {
+			t.Errorf("got a wall inside the arena on %v", snake.Coordinate{10, 3})
+		}
+	})
+
+	t.Run("should fall back to ASCII glyphs", func(t *testing.T) {
+		screen := tcell.NewSimulationScreen("US-ASCII")
+		err := screen.Init()
+		snake.AssertNoError(t, err)
+		screen.SetSize(width, height)
+		view := snake.NewView(screen)
+		defer view.Release()
+		coordinates := &[]snake.Coordinate{{1, 0}, {0, 0}, {0, 1}, {0, 2}}
+		wantRunes := []rune{'>', '+', '|', 'o'}
+
+		view.Refresh(coordinates, nil)
+
+		for i, c := range *coordinates {
+			r, _, _, _ := getBoardContent(view, screen, c)
+			assertCellRune(t, c.X, c.Y, r, wantRunes[i])
+		}
+	})
+
+	t.Run("should display square cells with half block renderer", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		view.SetRenderer(snake.HalfBlockRenderer{})
+
+		gotWidth, gotHeight := view.BoardSize()
+		assertBoardSize(t, gotWidth, gotHeight, width-2, (height-3)*2)
+
+		view.Refresh(&[]snake.Coordinate{{0, 0}, {0, 1}, {0, 2}}, &snake.Coordinate{3, 5})
+
+		r, _, s, _ := getBoardContent(view, screen, snake.Coordinate{0, 0})
+		assertCellRune(t, 0, 0, r, snake.UpperHalfBlockRune)
+		fg, bg, _ := s.Decompose()
+		assertForegroundColor(t, 0, 0, fg, snake.HeadForegroundColor)
+		assertBackgroundColor(t, 0, 0, bg, snake.BodyForegroundColor)
+		r, _, s, _ = getBoardContent(view, screen, snake.Coordinate{0, 2})
+		assertCellRune(t, 0, 2, r, snake.UpperHalfBlockRune)
+		fg, _, _ = s.Decompose()
+		assertForegroundColor(t, 0, 2, fg, snake.BodyForegroundColor)
+		r, _, s, _ = getBoardContent(view, screen, snake.Coordinate{3, 5})
+		assertCellRune(t, 3, 5, r, snake.LowerHalfBlockRune)
+		fg, _, _ = s.Decompose()
+		assertForegroundColor(t, 3, 5, fg, snake.FoodForegroundColor)
+	})
+
+	t.Run("should display board inside a frame", func(t *testing.T) {
+		view, screen := initView(t, 6, 6)
+		defer view.Release()
+		view.SetBoardSize(4, 3)
+
+		view.Refresh(&[]snake.Coordinate{{0, 0}, {1, 0}}, nil)
+
+		x, y := view.ToScreen(snake.Coordinate{0, 0})
+		if x != 1 || y != 1 {
+			t.Errorf("got board origin on screen cell [%d, %d], want [1, 1]", x, y)
+		}
+		corners := []struct {
+			x, y int
+			want rune
+		}{
+			{0, 0, tcell.RuneULCorner},
+			{5, 0, tcell.RuneURCorner},
+			{0, 4, tcell.RuneLLCorner},
+			{5, 4, tcell.RuneLRCorner},
+			{2, 0, tcell.RuneHLine},
+			{5, 2, tcell.RuneVLine},
+		}
+		for _, c := range corners {
+			r, _, _, _ := screen.GetContent(c.x, c.y)
+			assertCellRune(t, c.x, c.y, r, c.want)
+		}
+	})
+
+	t.Run("should display status below the frame", func(t *testing.T) {
+		view, screen := initView(t, 80, 8)
+		defer view.Release()
+		view.SetBoardSize(4, 3)
+		status := snake.Status{Score: 2, Length: 5, Speed: 200 * time.Millisecond, Mode: "Classic", Elapsed: 83 * time.Second}
+
+		view.Refresh(&[]snake.Coordinate{{0, 0}, {1, 0}}, nil)
+		view.RefreshStatus(status)
+
+		want := "Score: 2  Length: 5  Speed: 200ms  Mode: Classic  Time: 01:23"
+		_, y := view.ToScreen(snake.Coordinate{0, 0})
+		assertScreenLine(t, screen, 80-len(want), y+4, want)
+	})
+
+	t.Run("should center the frame after terminal resize", func(t *testing.T) {
+		view, screen := initView(t, 10, 8)
+		defer view.Release()
+		view.SetBoardSize(4, 3)
+		view.Refresh(&[]snake.Coordinate{{0, 0}, {1, 0}}, nil)
+
+		screen.SetSize(20, 10)
+		err := screen.PostEvent(tcell.NewEventResize(20, 10))
+		snake.AssertNoError(t, err)
+
+		got := <-view.ReceiveResize()
+		if got.Width != 18 || got.Height != 7 {
+			t.Errorf("got board size %v fitting the resized screen, want 18x7", got)
+		}
+		x, y := view.ToScreen(snake.Coordinate{0, 0})
+		if x != 8 || y != 3 {
+			t.Errorf("got board origin on screen cell [%d, %d], want [8, 3]", x, y)
+		}
+		r, _, _, _ := getBoardContent(view, screen, snake.Coordinate{0, 0})
+		assertCellRune(t, 0, 0, r, '◀')
+	})
+
+	t.Run("should display terminal too small when the board does not fit", func(t *testing.T) {
+		view, screen := initView(t, 80, 6)
+		defer view.Release()
+		view.SetBoardSize(30, 20)
+
+		view.Refresh(&[]snake.Coordinate{{0, 0}, {1, 0}}, nil)
+
+		assertScreenLine(t, screen, 0, 0, fmt.Sprintf(snake.TooSmallMessage, 12, 8))
+	})
+
+	t.Run("should follow the snake head on boards larger than the screen", func(t *testing.T) {
+		view, screen := initView(t, 32, 23)
+		defer view.Release()
+		view.SetBoardSize(100, 50)
+		coordinates := &[]snake.Coordinate{{80, 40}, {81, 40}, {82, 40}}
+
+		view.Refresh(coordinates, nil)
+
+		x, y := view.ToScreen((*coordinates)[0])
+		if x < 1 || x > 30 || y < 1 || y > 20 {
+			t.Fatalf("got head on screen cell [%d, %d], want it inside the viewport", x, y)
+		}
+		r, _, _, _ := screen.GetContent(x, y)
+		assertCellRune(t, 80, 40, r, '◀')
+	})
+
+	t.Run("should scroll the viewport out of the dead zone", func(t *testing.T) {
+		view, _ := initView(t, 12, 8)
+		defer view.Release()
+		view.SetBoardSize(40, 5)
+		view.SetDeadZone(2)
+
+		view.Refresh(&[]snake.Coordinate{{7, 2}, {6, 2}}, nil)
+		x, _ := view.ToScreen(snake.Coordinate{0, 2})
+		if x != 1 {
+			t.Errorf("got board origin on screen column %d, want 1", x)
+		}
+
+		view.Refresh(&[]snake.Coordinate{{8, 2}, {7, 2}}, nil)
+		x, _ = view.ToScreen(snake.Coordinate{1, 2})
+		if x != 1 {
+			t.Errorf("got board column 1 on screen column %d, want 1", x)
+		}
+	})
+
+	t.Run("should display the food on the minimap", func(t *testing.T) {
+		view, screen := initView(t, 60, 30)
+		defer view.Release()
+		view.SetBoardSize(100, 50)
+
+		view.Refresh(&[]snake.Coordinate{{80, 40}, {81, 40}}, &snake.Coordinate{0, 0})
+
+		// the minimap is 16x8 on the 58x27 viewport top right corner,
+		// each minimap cell covers 7x7 board cells
+		minimapX, minimapY := 1+58-snake.MinimapWidth, 1
+		r, _, s, _ := screen.GetContent(minimapX, minimapY)
+		assertCellRune(t, minimapX, minimapY, r, snake.FoodRune)
+		fg, _, _ := s.Decompose()
+		assertForegroundColor(t, minimapX, minimapY, fg, snake.FoodForegroundColor)
+		r, _, _, _ = screen.GetContent(minimapX+80/7, minimapY+40/7)
+		assertCellRune(t, minimapX+80/7, minimapY+40/7, r, snake.MinimapHeadRune)
+	})
+
 	directionTestCases := []struct {
 		key tcell.Key
 		dir snake.Direction
@@ -77,7 +402,7 @@ func TestView(t *testing.T) {
 		view.Refresh(nil, &snake.Coordinate{0, 0})
 		cells, _, _ := screen.GetContents()
 		for _, c := range cells {
-			if c.Runes[0] == snake.BodyRune {
+			if isSnakeRune(c.Runes[0]) {
 				t.Fatalf("got snake body rune")
 			}
 		}
@@ -101,14 +426,10 @@ func TestView(t *testing.T) {
 		defer view.Release()
 
 		view.DisplayWin()
-		i := 0
-		cells, _, _ := screen.GetContents()
-		for _, c := range snake.WinMessage {
-			got := cells[i].Runes[0]
-			if got != c {
-				t.Fatalf("got %c rune, want %c rune", got, c)
-			}
-			i++
+
+		assertScreenContains(t, screen, snake.WinTitle)
+		for _, line := range snake.WrapText(snake.WinMessage, width-4) {
+			assertScreenContains(t, screen, line)
 		}
 	})
 
@@ -117,14 +438,77 @@ func TestView(t *testing.T) {
 		defer view.Release()
 
 		view.DisplayLose()
-		i := 0
-		cells, _, _ := screen.GetContents()
-		for _, c := range snake.LoseMessage {
-			got := cells[i].Runes[0]
-			if got != c {
-				t.Fatalf("got %c rune, want %c rune", got, c)
-			}
-			i++
+
+		assertScreenContains(t, screen, snake.LoseTitle)
+		for _, line := range snake.WrapText(snake.LoseMessage, width-4) {
+			assertScreenContains(t, screen, line)
+		}
+	})
+
+	t.Run("should display dialog over the frozen board", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+		coordinates := &[]snake.Coordinate{{1, 1}, {2, 1}, {3, 1}}
+
+		view.Refresh(coordinates, nil)
+		view.DisplayLose()
+
+		r, _, _, _ := getBoardContent(view, screen, (*coordinates)[0])
+		assertCellRune(t, 1, 1, r, '◀')
+		assertScreenContains(t, screen, snake.LoseTitle)
+	})
+
+	t.Run("should word wrap dialog on narrow screens", func(t *testing.T) {
+		view, screen := initView(t, 24, 20)
+		defer view.Release()
+
+		view.DisplayWin()
+
+		lines := snake.WrapText(snake.WinMessage, 20)
+		if len(lines) < 2 {
+			t.Fatalf("got %d wrapped lines, want more than one", len(lines))
+		}
+		for _, line := range lines {
+			assertScreenContains(t, screen, line)
+		}
+	})
+
+	t.Run("should display menu with selected item", func(t *testing.T) {
+		view, screen := initView(t, 20, 10)
+		defer view.Release()
+
+		view.DisplayMenu(snake.Menu{Title: "Snake", Items: []string{"Play", "Quit"}, Selected: 1})
+
+		assertScreenContains(t, screen, " Snake ")
+		x, y := assertScreenContains(t, screen, "Play")
+		_, _, s, _ := screen.GetContent(x, y)
+		if _, _, attrs := s.Decompose(); attrs&tcell.AttrReverse != 0 {
+			t.Error("not selected item should not be highlighted")
+		}
+		x, y = assertScreenContains(t, screen, "Quit")
+		_, _, s, _ = screen.GetContent(x, y)
+		if _, _, attrs := s.Decompose(); attrs&tcell.AttrReverse == 0 {
+			t.Error("selected item should be highlighted")
+		}
+	})
+
+	t.Run("should send select signal on enter press", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+		<-view.ReceiveSelectSignal()
+	})
+
+	t.Run("should send pause signal on P and ESC press", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+
+		screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyRune, 'p', tcell.ModNone)
+		screen.InjectKey(tcell.KeyRune, 'P', tcell.ModNone)
+		for i := 0; i < 3; i++ {
+			<-view.ReceivePauseSignal()
 		}
 	})
 
@@ -133,11 +517,7 @@ func TestView(t *testing.T) {
 		defer view.Release()
 
 		screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
-		select {
-		case <-view.ReceiveNewGameSignal():
-		case <-time.After(time.Millisecond * 5):
-			t.Error("should have received a new game signal")
-		}
+		<-view.ReceiveNewGameSignal()
 	})
 
 	t.Run("should send quit game signal on Q press", func(t *testing.T) {
@@ -148,11 +528,41 @@ func TestView(t *testing.T) {
 
 		for _, r := range keys {
 			screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
-			select {
-			case <-view.ReceiveQuitSignal():
-			case <-time.After(time.Millisecond * 5):
-				t.Error("should have received a quit game signal")
-			}
+			<-view.ReceiveQuitSignal()
+		}
+	})
+
+	t.Run("should send quit game signal on CTRL+C press", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+		defer view.Release()
+
+		screen.InjectKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)
+		<-view.ReceiveQuitSignal()
+	})
+
+	t.Run("should release while a key press is not received", func(t *testing.T) {
+		view, screen := initView(t, width, height)
+
+		screen.InjectKey(tcell.KeyUp, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyRune, 'q', tcell.ModNone)
+		for screen.HasPendingEvent() {
+			runtime.Gosched()
+		}
+
+		view.Release()
+		if _, ok := <-view.ReceiveDirection(); ok {
+			t.Error("should have closed the direction channel")
+		}
+	})
+
+	t.Run("should release more than once closing the input channels", func(t *testing.T) {
+		snake.VerifyNoGoroutineLeaks(t)
+		view, _ := initView(t, width, height)
+
+		view.Release()
+		view.Release()
+		if _, ok := <-view.ReceiveQuitSignal(); ok {
+			t.Error("should have closed the quit channel")
 		}
 	})
 }
@@ -178,6 +588,61 @@ func assertBackgroundColor(t testing.TB, x, y int, got tcell.Color, want tcell.C
 	}
 }
 
+func isSnakeRune(r rune) bool {
+	g := snake.UnicodeGlyphs
+	for _, m := range []map[snake.Direction]rune{g.Head, g.Body, g.Tail} {
+		for _, gr := range m {
+			if r == gr {
+				return true
+			}
+		}
+	}
+	return r == g.Fallback
+}
+
+// showCounter counts the times the screen is shown.
+type showCounter struct {
+	tcell.SimulationScreen
+	shows int
+}
+
+func (s *showCounter) Show() {
+	s.shows++
+	s.SimulationScreen.Show()
+}
+
+func getBoardContent(view *snake.View, screen tcell.SimulationScreen, c snake.Coordinate) (rune, []rune, tcell.Style, int) {
+	x, y := view.ToScreen(c)
+	return screen.GetContent(x, y)
+}
+
+func assertScreenLine(t testing.TB, screen tcell.SimulationScreen, x, y int, want string) {
+	t.Helper()
+	for _, c := range want {
+		r, _, _, _ := screen.GetContent(x, y)
+		assertCellRune(t, x, y, r, c)
+		x++
+	}
+}
+
+// assertScreenContains asserts that a screen row contains want,
+// returning the screen cell of its first rune.
+func assertScreenContains(t testing.TB, screen tcell.SimulationScreen, want string) (int, int) {
+	t.Helper()
+	cells, width, height := screen.GetContents()
+	for y := 0; y < height; y++ {
+		var row []rune
+		for x := 0; x < width; x++ {
+			row = append(row, cells[y*width+x].Runes...)
+		}
+		if i := strings.Index(string(row), want); i >= 0 {
+			return utf8.RuneCountInString(string(row)[:i]), y
+		}
+	}
+	t.Errorf("screen should contain %q", want)
+	return -1, -1
+}
+
 func initView(t testing.TB, width, height int) (*snake.View, tcell.SimulationScreen) {
 	t.Helper()
 	screen := tcell.NewSimulationScreen("UTF-8")
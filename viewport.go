@@ -0,0 +1,135 @@
+package snake
+
+import "github.com/gdamore/tcell/v2"
+
+// MinViewportWidth and MinViewportHeight are the smallest board cells
+// shown by the viewport before the view asks to enlarge the terminal.
+const (
+	MinViewportWidth  = 10
+	MinViewportHeight = 5
+)
+
+// DefaultDeadZone is the default distance, in cells, between the snake
+// head and the viewport sides before the viewport scrolls.
+const DefaultDeadZone = 5
+
+const MinimapWidth = 16
+const MinimapHeight = 8
+const MinimapHeadRune = '@'
+const MinimapBodyRune = '*'
+const MinimapBackgroundColor = tcell.ColorBlack
+const MinimapViewportColor = tcell.ColorDarkSlateGray
+
+// viewport returns the board width and height shown inside the frame,
+// which is the whole board if it fits the screen.
+func (v *View) viewport() (int, int) {
+	boardWidth, boardHeight := v.board()
+	fitWidth, fitHeight := v.fitBoard()
+	return minInt(boardWidth, fitWidth), minInt(boardHeight, fitHeight)
+}
+
+// follow scrolls the viewport to keep head at least dead zone cells
+// away from the viewport sides.
+func (v *View) follow(head Coordinate) {
+	boardWidth, boardHeight := v.board()
+	width, height := v.viewport()
+	v.camera.X = followAxis(v.camera.X, width, boardWidth, head.X, v.deadZone)
+	v.camera.Y = followAxis(v.camera.Y, height, boardHeight, head.Y, v.deadZone)
+}
+
+// followAxis returns the viewport position on one board axis.
+func followAxis(position, size, boardSize, head, deadZone int) int {
+	if size >= boardSize {
+		return 0
+	}
+	deadZone = minInt(deadZone, (size-1)/2)
+	if head < position+deadZone {
+		position = head - deadZone
+	}
+	if head > position+size-1-deadZone {
+		position = head - size + 1 + deadZone
+	}
+	if position < 0 {
+		position = 0
+	}
+	if position > boardSize-size {
+		position = boardSize - size
+	}
+	return position
+}
+
+// setCell sets the viewport cell of the board coordinate c,
+// ignoring coordinates out of the viewport.
+func (v *View) setCell(cells [][]Cell, c Coordinate, cell Cell) {
+	setCell(cells, Coordinate{c.X - v.camera.X, c.Y - v.camera.Y}, cell)
+}
+
+// drawMinimap draws a scaled down board in the frame top right corner,
+// with the snake, the food and the viewport area, if the viewport does not
+// show the whole board.
+func (v *View) drawMinimap() {
+	boardWidth, boardHeight := v.board()
+	width, height := v.viewport()
+	if width >= boardWidth && height >= boardHeight {
+		return
+	}
+	cols, rows := v.renderer.ScreenSize(width, height)
+	miniWidth, miniHeight := minInt(MinimapWidth, cols/3), minInt(MinimapHeight, rows/3)
+	if miniWidth == 0 || miniHeight == 0 {
+		return
+	}
+	scaleX, scaleY := ceilDiv(boardWidth, miniWidth), ceilDiv(boardHeight, miniHeight)
+	miniCell := func(c Coordinate) Coordinate {
+		return Coordinate{c.X / scaleX, c.Y / scaleY}
+	}
+	cells := newCells(miniWidth, miniHeight)
+	background := tcell.StyleDefault.Background(MinimapBackgroundColor)
+	for y := range cells {
+		for x := range cells[y] {
+			cells[y][x] = Cell{' ', background, MinimapBackgroundColor}
+		}
+	}
+	topLeft := miniCell(v.camera)
+	bottomRight := miniCell(Coordinate{v.camera.X + width - 1, v.camera.Y + height - 1})
+	viewportStyle := tcell.StyleDefault.Background(MinimapViewportColor)
+	for y := topLeft.Y; y <= bottomRight.Y; y++ {
+		for x := topLeft.X; x <= bottomRight.X; x++ {
+			setCell(cells, Coordinate{x, y}, Cell{' ', viewportStyle, MinimapViewportColor})
+		}
+	}
+	if v.snake != nil {
+		for i := len(*v.snake) - 1; i >= 0; i-- {
+			c := miniCell((*v.snake)[i])
+			r, color := MinimapBodyRune, BodyForegroundColor
+			if i == 0 {
+				r, color = MinimapHeadRune, HeadForegroundColor
+			}
+			setCell(cells, c, Cell{r, cellStyle(cells, c).Foreground(color), color})
+		}
+	}
+	if v.food != nil {
+		c := miniCell(*v.food)
+		setCell(cells, c, Cell{FoodRune, cellStyle(cells, c).Foreground(FoodForegroundColor), FoodForegroundColor})
+	}
+	originX, originY := v.origin()
+	TextRenderer{}.Draw(v.screen, originX+cols-miniWidth, originY, cells)
+}
+
+// cellStyle returns the style of the cell at coordinate c.
+func cellStyle(cells [][]Cell, c Coordinate) tcell.Style {
+	if c.Y < 0 || c.Y >= len(cells) || c.X < 0 || c.X >= len(cells[c.Y]) {
+		return tcell.StyleDefault
+	}
+	return cells[c.Y][c.X].Style
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
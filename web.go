@@ -0,0 +1,418 @@
+package snake
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"sync"
+
+	"github.com/castagnadaniele/go-snake/internal/websocket"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// WebSocketPath is the path on which the WebView accepts WebSocket connections.
+const WebSocketPath = "/ws"
+
+// WebState is the message the WebView sends to the browsers
+// each time the displayed game changes. The board cells outside
+// the Arena, if any, are walls.
+type WebState struct {
+	Width     int             `json:"width"`
+	Height    int             `json:"height"`
+	Snake     []Coordinate    `json:"snake"`
+	Food      *Coordinate     `json:"food"`
+	Opponents []OpponentState `json:"opponents,omitempty"`
+	Arena     *Bounds         `json:"arena,omitempty"`
+	Status    string          `json:"status"`
+	Menu      *Menu           `json:"menu"`
+	Dialog    *WebDialog      `json:"dialog"`
+}
+
+// WebDialog is a dialog displayed by the browsers over the board.
+type WebDialog struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// WebInput is the message the browsers send to the WebView. Type is one of
+// "direction", "select", "pause", "save", "newGame" or "quit". Direction is one of
+// "Up", "Down", "Left" or "Right", set only on direction inputs.
+type WebInput struct {
+	Type      string `json:"type"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// WebView struct serves the snake game to browsers: it is an http.Handler
+// which serves a static page drawing the board on a canvas and streams
+// the game state over WebSocket connections, receiving the user inputs.
+type WebView struct {
+	directionC chan Direction
+	newGameC   chan struct{}
+	quitGameC  chan struct{}
+	selectC    chan struct{}
+	pauseC     chan struct{}
+	saveC      chan struct{}
+	resizeC    chan Size
+	doneC      chan struct{}
+	files      http.Handler
+	state      WebState
+	clients    map[*webClient]struct{}
+	readOnly   bool
+	origins    []string
+	closeOnce  sync.Once
+	mu         sync.Mutex
+}
+
+// webClient is a browser connected to the WebView. Its stateC holds
+// the last state which has not been written to the browser yet, so a slow
+// browser skips intermediate states instead of slowing the game down.
+type webClient struct {
+	conn   *websocket.Conn
+	stateC chan []byte
+}
+
+// NewWebView returns a WebView struct pointer displaying a board
+// of width and height cells.
+func NewWebView(width, height int) *WebView {
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+	return &WebView{
+		directionC: make(chan Direction),
+		newGameC:   make(chan struct{}),
+		quitGameC:  make(chan struct{}),
+		selectC:    make(chan struct{}),
+		pauseC:     make(chan struct{}),
+		saveC:      make(chan struct{}),
+		resizeC:    make(chan Size),
+		doneC:      make(chan struct{}),
+		files:      http.FileServer(http.FS(static)),
+		state:      WebState{Width: width, Height: height},
+		clients:    make(map[*webClient]struct{}),
+	}
+}
+
+// SetReadOnly sets whether the browsers inputs are discarded, so that
+// the browsers can watch the game without playing it.
+func (v *WebView) SetReadOnly(readOnly bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.readOnly = readOnly
+}
+
+func (v *WebView) isReadOnly() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.readOnly
+}
+
+// SetAllowedOrigins sets the origins, besides the one of the WebView host,
+// whose pages can open WebSocket connections to the WebView.
+func (v *WebView) SetAllowedOrigins(origins ...string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.origins = origins
+}
+
+func (v *WebView) allowedOrigins() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.origins
+}
+
+// ServeHTTP upgrades the requests on WebSocketPath to WebSocket connections
+// and serves the static page on the other paths. Upgrades from pages served
+// by other hosts are rejected, unless their origin is allowed.
+func (v *WebView) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != WebSocketPath {
+		v.files.ServeHTTP(w, r)
+		return
+	}
+	conn, err := websocket.Upgrade(w, r, v.allowedOrigins()...)
+	if err != nil {
+		return
+	}
+	client := &webClient{conn, make(chan []byte, 1)}
+	v.mu.Lock()
+	select {
+	case <-v.doneC:
+		v.mu.Unlock()
+		conn.Close()
+		return
+	default:
+	}
+	v.clients[client] = struct{}{}
+	client.send(v.encodeState())
+	v.mu.Unlock()
+
+	go v.writeRoutine(client)
+	v.readRoutine(client)
+}
+
+// readRoutine reads the client inputs and sends them on the view channels,
+// until the connection is closed or the view is released.
+func (v *WebView) readRoutine(client *webClient) {
+	defer v.removeClient(client)
+	for {
+		message, err := client.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var input WebInput
+		if v.isReadOnly() || json.Unmarshal(message, &input) != nil {
+			continue
+		}
+		switch input.Type {
+		case "direction":
+			d, ok := parseDirection(input.Direction)
+			if !ok {
+				continue
+			}
+			select {
+			case v.directionC <- d:
+			case <-v.doneC:
+				return
+			}
+		case "select":
+			v.signal(v.selectC)
+		case "pause":
+			v.signal(v.pauseC)
+		case "newGame":
+			v.signal(v.newGameC)
+		case "save":
+			v.signal(v.saveC)
+		case "quit":
+			v.signal(v.quitGameC)
+		}
+	}
+}
+
+// writeRoutine writes the states queued for the client,
+// until the client is removed.
+func (v *WebView) writeRoutine(client *webClient) {
+	for state := range client.stateC {
+		if err := client.conn.WriteMessage(state); err != nil {
+			client.conn.Close()
+			return
+		}
+	}
+}
+
+// signal sends on c unless the view is released.
+func (v *WebView) signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	case <-v.doneC:
+	}
+}
+
+// removeClient closes the client state channel and its connection,
+// which is closed after unlocking the view mutex so that a stalled browser
+// does not block the other clients.
+func (v *WebView) removeClient(client *webClient) {
+	v.mu.Lock()
+	if _, ok := v.clients[client]; !ok {
+		v.mu.Unlock()
+		return
+	}
+	delete(v.clients, client)
+	close(client.stateC)
+	v.mu.Unlock()
+	client.conn.Close()
+}
+
+// send queues state, replacing the state queued before if it
+// has not been written yet. It should be called with the view mutex locked.
+func (c *webClient) send(state []byte) {
+	select {
+	case <-c.stateC:
+	default:
+	}
+	c.stateC <- state
+}
+
+// encodeState returns the JSON encoded view state.
+// It should be called with the view mutex locked.
+func (v *WebView) encodeState() []byte {
+	state, err := json.Marshal(v.state)
+	if err != nil {
+		panic(err)
+	}
+	return state
+}
+
+// broadcast sends the view state to all the clients.
+// It should be called with the view mutex locked.
+func (v *WebView) broadcast() {
+	state := v.encodeState()
+	for client := range v.clients {
+		client.send(state)
+	}
+}
+
+// Refresh sends the snake and food coordinates to the browsers,
+// hiding the menu and the dialog.
+func (v *WebView) Refresh(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.setBoard(snakeCoordinates, foodCoordinate)
+	v.broadcast()
+}
+
+// RefreshFrame sends the snake and food coordinates and the game status
+// to the browsers in a single message, hiding the menu and the dialog.
+func (v *WebView) RefreshFrame(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate, s Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.setBoard(snakeCoordinates, foodCoordinate)
+	v.state.Status = s.String()
+	v.broadcast()
+}
+
+// setBoard sets the snake and food coordinates of the view state,
+// hiding the menu and the dialog.
+// It should be called with the view mutex locked.
+func (v *WebView) setBoard(snakeCoordinates *[]Coordinate, foodCoordinate *Coordinate) {
+	v.state.Snake = nil
+	if snakeCoordinates != nil {
+		v.state.Snake = append([]Coordinate{}, *snakeCoordinates...)
+	}
+	v.state.Food = nil
+	if foodCoordinate != nil {
+		food := *foodCoordinate
+		v.state.Food = &food
+	}
+	v.state.Menu, v.state.Dialog = nil, nil
+}
+
+// RefreshStatus sends the game status to the browsers.
+func (v *WebView) RefreshStatus(s Status) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Status = s.String()
+	v.broadcast()
+}
+
+// DisplayWin sends a win dialog to the browsers.
+func (v *WebView) DisplayWin() {
+	v.displayDialog(WinTitle, WinMessage)
+}
+
+// DisplayLose sends a lose dialog to the browsers.
+func (v *WebView) DisplayLose() {
+	v.displayDialog(LoseTitle, LoseMessage)
+}
+
+func (v *WebView) displayDialog(title, message string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Menu, v.state.Dialog = nil, &WebDialog{title, message}
+	v.broadcast()
+}
+
+// DisplayMenu sends m to the browsers.
+func (v *WebView) DisplayMenu(m Menu) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Menu, v.state.Dialog = &m, nil
+	v.broadcast()
+}
+
+// SetOpponents sets the opponent snakes sent to the browsers with the next
+// refresh. The browsers do not draw the dead opponents.
+func (v *WebView) SetOpponents(opponents []OpponentState) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Opponents = append([]OpponentState(nil), opponents...)
+}
+
+// SetArena sets the arena bounds sent to the browsers with the next
+// refresh. The zero Bounds send no walls.
+func (v *WebView) SetArena(b Bounds) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Arena = nil
+	if b != (Bounds{}) {
+		v.state.Arena = &b
+	}
+}
+
+// SetBoardSize sets the board width and height sent to the browsers.
+func (v *WebView) SetBoardSize(width, height int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.state.Width, v.state.Height = width, height
+	v.broadcast()
+}
+
+// ReceiveDirection returns a Direction receiver channel on which
+// the view sends the browsers direction inputs.
+func (v *WebView) ReceiveDirection() <-chan Direction {
+	return v.directionC
+}
+
+// ReceiveNewGameSignal returns an empty struct receiver channel on which
+// the view sends the browsers new game inputs.
+func (v *WebView) ReceiveNewGameSignal() <-chan struct{} {
+	return v.newGameC
+}
+
+// ReceiveQuitSignal returns an empty struct receiver channel on which
+// the view sends the browsers quit inputs.
+func (v *WebView) ReceiveQuitSignal() <-chan struct{} {
+	return v.quitGameC
+}
+
+// ReceiveSelectSignal returns an empty struct receiver channel on which
+// the view sends the browsers menu item selection inputs.
+func (v *WebView) ReceiveSelectSignal() <-chan struct{} {
+	return v.selectC
+}
+
+// ReceivePauseSignal returns an empty struct receiver channel on which
+// the view sends the browsers pause or back inputs.
+func (v *WebView) ReceivePauseSignal() <-chan struct{} {
+	return v.pauseC
+}
+
+// ReceiveSaveSignal returns an empty struct receiver channel on which
+// the view sends the browsers save inputs.
+func (v *WebView) ReceiveSaveSignal() <-chan struct{} {
+	return v.saveC
+}
+
+// ReceiveResize returns a Size receiver channel which never receives:
+// the browsers scale the canvas to the board instead of resizing it.
+func (v *WebView) ReceiveResize() <-chan Size {
+	return v.resizeC
+}
+
+// Release closes the browsers connections and stops sending the inputs.
+func (v *WebView) Release() {
+	v.closeOnce.Do(func() {
+		v.mu.Lock()
+		close(v.doneC)
+		clients := make([]*webClient, 0, len(v.clients))
+		for client := range v.clients {
+			clients = append(clients, client)
+		}
+		v.mu.Unlock()
+		for _, client := range clients {
+			v.removeClient(client)
+		}
+	})
+}
+
+// parseDirection returns the Direction named s.
+func parseDirection(s string) (Direction, bool) {
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		if d.String() == s {
+			return d, true
+		}
+	}
+	return 0, false
+}
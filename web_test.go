@@ -0,0 +1,194 @@
+package snake_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/castagnadaniele/go-snake"
+	"github.com/castagnadaniele/go-snake/internal/websocket"
+)
+
+func TestWebView(t *testing.T) {
+	t.Run("should serve the game page", func(t *testing.T) {
+		_, server := initWebView(t)
+
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "<canvas") {
+			t.Errorf("got status %d, want the game page", resp.StatusCode)
+		}
+	})
+
+	t.Run("should send the current state on connect", func(t *testing.T) {
+		view, server := initWebView(t)
+		view.DisplayMenu(snake.Menu{Title: "Snake", Items: []string{"Play"}})
+
+		conn := dialWebView(t, server)
+		got := receiveWebState(t, conn)
+
+		if got.Width != 10 || got.Height != 8 {
+			t.Errorf("got board size %dx%d, want 10x8", got.Width, got.Height)
+		}
+		if got.Menu == nil || got.Menu.Title != "Snake" {
+			t.Errorf("got menu %v, want Snake menu", got.Menu)
+		}
+	})
+
+	t.Run("should send the snake and food coordinates on refresh", func(t *testing.T) {
+		view, server := initWebView(t)
+		conn := dialWebView(t, server)
+		receiveWebState(t, conn)
+		coordinates := []snake.Coordinate{{X: 1, Y: 1}, {X: 2, Y: 1}}
+		food := snake.Coordinate{X: 5, Y: 5}
+
+		view.Refresh(&coordinates, &food)
+		got := receiveWebState(t, conn)
+
+		snake.AssertCoordinates(t, got.Snake, coordinates)
+		if got.Food == nil || *got.Food != food {
+			t.Errorf("got food %v, want %v", got.Food, food)
+		}
+	})
+
+	t.Run("should send the status and the dialogs", func(t *testing.T) {
+		view, server := initWebView(t)
+		conn := dialWebView(t, server)
+		receiveWebState(t, conn)
+
+		view.RefreshStatus(snake.Status{Score: 3, Mode: snake.DefaultMode})
+		got := receiveWebState(t, conn)
+		if !strings.Contains(got.Status, "Score: 3") {
+			t.Errorf("got status %q, want score 3", got.Status)
+		}
+
+		view.DisplayLose()
+		got = receiveWebState(t, conn)
+		if got.Dialog == nil || got.Dialog.Title != snake.LoseTitle {
+			t.Errorf("got dialog %v, want %q dialog", got.Dialog, snake.LoseTitle)
+		}
+	})
+
+	t.Run("should send the browser inputs", func(t *testing.T) {
+		view, server := initWebView(t)
+		conn := dialWebView(t, server)
+		receiveWebState(t, conn)
+
+		sendWebInput(t, conn, snake.WebInput{Type: "direction", Direction: "Left"})
+		d := <-view.ReceiveDirection()
+		if d != snake.Left {
+			t.Errorf("got direction %v, want %v", d, snake.Left)
+		}
+
+		inputs := []struct {
+			input string
+			c     <-chan struct{}
+		}{
+			{"select", view.ReceiveSelectSignal()},
+			{"pause", view.ReceivePauseSignal()},
+			{"newGame", view.ReceiveNewGameSignal()},
+			{"quit", view.ReceiveQuitSignal()},
+		}
+		for _, i := range inputs {
+			sendWebInput(t, conn, snake.WebInput{Type: i.input})
+			<-i.c
+		}
+	})
+
+	t.Run("should reject connections from other origins", func(t *testing.T) {
+		view, server := initWebView(t)
+		request := func(origin string) int {
+			t.Helper()
+			req, err := http.NewRequest(http.MethodGet, server.URL+snake.WebSocketPath, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			req.Header.Set("Origin", origin)
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+
+		if got := request("http://evil.example"); got != http.StatusForbidden {
+			t.Errorf("got status %d, want %d", got, http.StatusForbidden)
+		}
+		view.SetAllowedOrigins("http://evil.example")
+		if got := request("http://evil.example"); got != http.StatusSwitchingProtocols {
+			t.Errorf("got status %d from an allowed origin, want %d", got, http.StatusSwitchingProtocols)
+		}
+		if got := request(server.URL); got != http.StatusSwitchingProtocols {
+			t.Errorf("got status %d from the same origin, want %d", got, http.StatusSwitchingProtocols)
+		}
+	})
+
+	t.Run("should close the connections on release", func(t *testing.T) {
+		view, server := initWebView(t)
+		conn := dialWebView(t, server)
+		receiveWebState(t, conn)
+
+		view.Release()
+
+		if _, err := conn.ReadMessage(); err == nil {
+			t.Error("should close the connection")
+		}
+	})
+}
+
+func initWebView(t testing.TB) (*snake.WebView, *httptest.Server) {
+	t.Helper()
+	view := snake.NewWebView(10, 8)
+	server := httptest.NewServer(view)
+	t.Cleanup(func() {
+		view.Release()
+		server.Close()
+	})
+	return view, server
+}
+
+func dialWebView(t testing.TB, server *httptest.Server) *websocket.Conn {
+	t.Helper()
+	conn, err := websocket.Dial("ws" + strings.TrimPrefix(server.URL, "http") + snake.WebSocketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func receiveWebState(t testing.TB, conn *websocket.Conn) snake.WebState {
+	t.Helper()
+	message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state snake.WebState
+	if err := json.Unmarshal(message, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func sendWebInput(t testing.TB, conn *websocket.Conn, input snake.WebInput) {
+	t.Helper()
+	message, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.WriteMessage(message); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,265 @@
+package snake
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ErrInvalidGoal = SnakeErr("snake: invalid goal")
+
+// Progress is the state of a game in progress on which the win conditions
+// are evaluated: the game score, the number of food and mice eaten, the snake
+// length and the game time elapsed, which is the number of ticks by the
+// interval, so the pauses do not count.
+type Progress struct {
+	Score   int
+	Eaten   int
+	Length  int
+	Elapsed time.Duration
+}
+
+// WinCondition wins the game when it is met. Game evaluates it after
+// each tick, besides filling the board which always wins.
+type WinCondition interface {
+	Met(p Progress) bool
+	String() string
+}
+
+// Goal is a win condition made of targets: eat Food food and mice, reach
+// Score points, reach Length cells and survive Survive game time. The goal
+// is met when all its targets are met, or any of them if Any is set. The zero
+// targets are not required, so the zero Goal is never met.
+type Goal struct {
+	Food    int           `json:"food,omitempty"`
+	Score   int           `json:"score,omitempty"`
+	Length  int           `json:"length,omitempty"`
+	Survive time.Duration `json:"survive,omitempty"`
+	Any     bool          `json:"any,omitempty"`
+}
+
+// valid reports whether the goal targets are not negative.
+func (g Goal) valid() bool {
+	return g.Food >= 0 && g.Score >= 0 && g.Length >= 0 && g.Survive >= 0
+}
+
+// targets returns the conditions of the goal targets which are set.
+func (g Goal) targets() []WinCondition {
+	var targets []WinCondition
+	if g.Food > 0 {
+		targets = append(targets, Eat(g.Food))
+	}
+	if g.Score > 0 {
+		targets = append(targets, TargetScore(g.Score))
+	}
+	if g.Length > 0 {
+		targets = append(targets, TargetLength(g.Length))
+	}
+	if g.Survive > 0 {
+		targets = append(targets, Survive(g.Survive))
+	}
+	return targets
+}
+
+// condition returns the combination of the goal targets.
+func (g Goal) condition() WinCondition {
+	if g.Any {
+		return AnyOf(g.targets()...)
+	}
+	return AllOf(g.targets()...)
+}
+
+func (g Goal) Met(p Progress) bool {
+	return g.condition().Met(p)
+}
+
+func (g Goal) String() string {
+	return g.condition().String()
+}
+
+// ParseGoal parses a goal from a list of targets, like "food=5,length=12",
+// which are all required when separated by commas, or any of them when
+// separated by vertical bars, like "score=20|survive=2m". The target names
+// are food, score, length and survive, whose value is a duration.
+// It returns ErrInvalidGoal if s is not a valid goal.
+func ParseGoal(s string) (Goal, error) {
+	var goal Goal
+	if s == "" {
+		return goal, nil
+	}
+	separator := ","
+	if strings.Contains(s, "|") {
+		if strings.Contains(s, ",") {
+			return Goal{}, fmt.Errorf("%w: can not mix , and |", ErrInvalidGoal)
+		}
+		separator, goal.Any = "|", true
+	}
+	for _, target := range strings.Split(s, separator) {
+		name, value := target, ""
+		if i := strings.Index(target, "="); i >= 0 {
+			name, value = strings.TrimSpace(target[:i]), strings.TrimSpace(target[i+1:])
+		}
+		var err error
+		switch name {
+		case "food":
+			goal.Food, err = strconv.Atoi(value)
+		case "score":
+			goal.Score, err = strconv.Atoi(value)
+		case "length":
+			goal.Length, err = strconv.Atoi(value)
+		case "survive":
+			goal.Survive, err = time.ParseDuration(value)
+		default:
+			return Goal{}, fmt.Errorf("%w: unknown target %q", ErrInvalidGoal, name)
+		}
+		if err != nil {
+			return Goal{}, fmt.Errorf("%w: %v", ErrInvalidGoal, err)
+		}
+	}
+	if !goal.valid() {
+		return Goal{}, ErrInvalidGoal
+	}
+	return goal, nil
+}
+
+// goalOf returns the goal expressing the win condition w, which can be
+// nil, a Goal, a positive target or the combination by AllOf or AnyOf of
+// positive targets of different kinds. It returns false if no goal
+// expresses w.
+func goalOf(w WinCondition) (Goal, bool) {
+	var goal Goal
+	var targets []WinCondition
+	switch c := w.(type) {
+	case nil:
+		return goal, true
+	case Goal:
+		return c, true
+	case allOf:
+		targets = c
+	case anyOf:
+		targets, goal.Any = c, true
+	default:
+		targets = []WinCondition{w}
+	}
+	for _, target := range targets {
+		ok := false
+		switch t := target.(type) {
+		case Eat:
+			ok, goal.Food = goal.Food == 0 && t > 0, int(t)
+		case TargetScore:
+			ok, goal.Score = goal.Score == 0 && t > 0, int(t)
+		case TargetLength:
+			ok, goal.Length = goal.Length == 0 && t > 0, int(t)
+		case Survive:
+			ok, goal.Survive = goal.Survive == 0 && t > 0, time.Duration(t)
+		}
+		if !ok {
+			return Goal{}, false
+		}
+	}
+	return goal, true
+}
+
+// TargetLength is met when the snake reaches its length.
+type TargetLength int
+
+func (t TargetLength) Met(p Progress) bool {
+	return p.Length >= int(t)
+}
+
+func (t TargetLength) String() string {
+	return fmt.Sprintf("reach length %d", int(t))
+}
+
+// TargetScore is met when the game score reaches it.
+type TargetScore int
+
+func (t TargetScore) Met(p Progress) bool {
+	return p.Score >= int(t)
+}
+
+func (t TargetScore) String() string {
+	return fmt.Sprintf("score %d points", int(t))
+}
+
+// Survive is met when the game time reaches its duration.
+type Survive time.Duration
+
+func (t Survive) Met(p Progress) bool {
+	return p.Elapsed >= time.Duration(t)
+}
+
+func (t Survive) String() string {
+	return fmt.Sprintf("survive %v", time.Duration(t))
+}
+
+// Eat is met when the snake ate its number of food and mice.
+type Eat int
+
+func (t Eat) Met(p Progress) bool {
+	return p.Eaten >= int(t)
+}
+
+func (t Eat) String() string {
+	return fmt.Sprintf("eat %d food", int(t))
+}
+
+// allOf is the win condition met when all its conditions are met.
+type allOf []WinCondition
+
+// AllOf returns the win condition met when all the conditions are met.
+// It is never met without conditions.
+func AllOf(conditions ...WinCondition) WinCondition {
+	return allOf(conditions)
+}
+
+func (c allOf) Met(p Progress) bool {
+	for _, condition := range c {
+		if !condition.Met(p) {
+			return false
+		}
+	}
+	return len(c) > 0
+}
+
+func (c allOf) String() string {
+	return joinConditions(c, ", ")
+}
+
+// anyOf is the win condition met when one of its conditions is met.
+type anyOf []WinCondition
+
+// AnyOf returns the win condition met when one of the conditions is met.
+// It is never met without conditions.
+func AnyOf(conditions ...WinCondition) WinCondition {
+	return anyOf(conditions)
+}
+
+func (c anyOf) Met(p Progress) bool {
+	for _, condition := range c {
+		if condition.Met(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c anyOf) String() string {
+	return joinConditions(c, " or ")
+}
+
+// joinConditions joins the descriptions of conditions with separator,
+// capitalizing the first letter. Without conditions the only way to win
+// is filling the board.
+func joinConditions(conditions []WinCondition, separator string) string {
+	if len(conditions) == 0 {
+		return "Fill the board"
+	}
+	descriptions := make([]string, len(conditions))
+	for i, c := range conditions {
+		descriptions[i] = c.String()
+	}
+	s := strings.Join(descriptions, separator)
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,169 @@
+package snake_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/castagnadaniele/go-snake"
+)
+
+func TestWinCondition(t *testing.T) {
+	progress := snake.Progress{Score: 4, Eaten: 3, Length: 6, Elapsed: 10 * time.Second}
+
+	t.Run("should meet the targets reached by the game", func(t *testing.T) {
+		cases := []struct {
+			condition snake.WinCondition
+			want      bool
+		}{
+			{snake.TargetLength(6), true},
+			{snake.TargetLength(7), false},
+			{snake.TargetScore(4), true},
+			{snake.TargetScore(5), false},
+			{snake.Eat(3), true},
+			{snake.Eat(4), false},
+			{snake.Survive(10 * time.Second), true},
+			{snake.Survive(11 * time.Second), false},
+			{snake.AllOf(snake.Eat(3), snake.TargetLength(6)), true},
+			{snake.AllOf(snake.Eat(3), snake.TargetLength(7)), false},
+			{snake.AnyOf(snake.Eat(4), snake.TargetLength(6)), true},
+			{snake.AnyOf(snake.Eat(4), snake.TargetLength(7)), false},
+			{snake.AllOf(), false},
+			{snake.AnyOf(), false},
+			{snake.Goal{Food: 3, Score: 4}, true},
+			{snake.Goal{Food: 3, Score: 5}, false},
+			{snake.Goal{Food: 3, Score: 5, Any: true}, true},
+			{snake.Goal{}, false},
+			{snake.AnyOf(snake.Goal{Length: 7}, snake.Survive(time.Second)), true},
+		}
+		for _, c := range cases {
+			if got := c.condition.Met(progress); got != c.want {
+				t.Errorf("got %v meeting %q, want %v", got, c.condition, c.want)
+			}
+		}
+	})
+
+	t.Run("should parse goals", func(t *testing.T) {
+		cases := map[string]snake.Goal{
+			"":                           {},
+			"food=5,length=12":           {Food: 5, Length: 12},
+			"score=20|survive=2m":        {Score: 20, Survive: 2 * time.Minute, Any: true},
+			"length = 8":                 {Length: 8},
+			"survive=90s,score=1,food=2": {Food: 2, Score: 1, Survive: 90 * time.Second},
+		}
+		for s, want := range cases {
+			got, err := snake.ParseGoal(s)
+			snake.AssertNoError(t, err)
+			if got != want {
+				t.Errorf("got goal %+v parsing %q, want %+v", got, s, want)
+			}
+		}
+		if got := (snake.Goal{Score: 20, Survive: 2 * time.Minute, Any: true}).String(); got != "Score 20 points or survive 2m0s" {
+			t.Errorf("got goal %q, want %q", got, "Score 20 points or survive 2m0s")
+		}
+	})
+
+	t.Run("should not parse invalid goals", func(t *testing.T) {
+		for _, s := range []string{"food", "food=x", "speed=3", "length=-1", "food=1,length=2|score=3", "survive=3"} {
+			if _, err := snake.ParseGoal(s); !errors.Is(err, snake.ErrInvalidGoal) {
+				t.Errorf("got error %v parsing %q, want %v", err, s, snake.ErrInvalidGoal)
+			}
+		}
+	})
+
+	t.Run("game should be won by any win condition", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{35, 30}, nil}, {snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+		defer g.Quit()
+		g.SetWinCondition(snake.AnyOf(snake.TargetScore(1), snake.Survive(time.Hour)))
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+
+		clock.Advance(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		_, r, _ := snake.WaitAndReceiveGameChannels(t, g)
+		assertGameResult(t, r, true)
+		want := snake.Goal{Score: 1, Survive: time.Hour, Any: true}
+		if s := g.Save(); s.Eaten != 1 || s.Goal != want {
+			t.Errorf("got eaten %d and goal %+v, want 1 eaten and goal %+v", s.Eaten, s.Goal, want)
+		}
+	})
+
+	t.Run("should save and restore the goal and the eaten food", func(t *testing.T) {
+		fs := &snake.FoodStub{}
+		fs.Seed([]snake.FoodStubValue{{snake.Coordinate{35, 30}, nil}, {snake.Coordinate{0, 0}, nil}})
+		clock := snake.NewFakeClock(time.Now())
+		g := snake.NewGame(snake.NewSnake(60, 60), clock, fs)
+		defer g.Quit()
+		goal := snake.Goal{Food: 2, Length: 3}
+		g.SetWinCondition(goal)
+		g.Start(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		clock.Advance(time.Microsecond)
+		snake.WaitAndReceiveGameChannels(t, g)
+		snake.WaitAndReceiveGameChannels(t, g)
+		g.Pause()
+
+		s := g.Save()
+		if s.Eaten != 1 || s.Goal != goal {
+			t.Fatalf("got eaten %d and goal %+v, want 1 eaten and goal %+v", s.Eaten, s.Goal, goal)
+		}
+		restored := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+		defer restored.Quit()
+		snake.AssertNoError(t, restored.Restore(s))
+		if got := restored.Save(); got.Eaten != 1 || got.Goal != goal {
+			t.Errorf("got eaten %d and goal %+v after restoring, want 1 eaten and goal %+v", got.Eaten, got.Goal, goal)
+		}
+	})
+	t.Run("should save the win conditions expressed by a goal", func(t *testing.T) {
+		cases := []struct {
+			win  snake.WinCondition
+			want snake.Goal
+		}{
+			{snake.Goal{Score: 3}, snake.Goal{Score: 3}},
+			{snake.Survive(time.Minute), snake.Goal{Survive: time.Minute}},
+			{snake.AllOf(snake.Eat(2), snake.TargetLength(3)), snake.Goal{Food: 2, Length: 3}},
+			{snake.AnyOf(snake.TargetScore(5), snake.Eat(4)), snake.Goal{Score: 5, Food: 4, Any: true}},
+			{snake.AllOf(snake.Eat(1), snake.Eat(2)), snake.Goal{}},
+			{snake.AnyOf(snake.AllOf(snake.Eat(1)), snake.TargetScore(2)), snake.Goal{}},
+		}
+		for _, c := range cases {
+			g := snake.NewGame(snake.NewSnake(60, 60), snake.NewFakeClock(time.Now()), &snake.FoodStub{})
+			g.SetWinCondition(c.win)
+			if got := g.Save().Goal; got != c.want {
+				t.Errorf("got goal %+v saved for %v, want %+v", got, c.win, c.want)
+			}
+		}
+	})
+
+	t.Run("controller should not save a win condition which no goal expresses", func(t *testing.T) {
+		view := NewViewSpy()
+		game := NewGameSpy()
+		game.SaveState = newSaveState(t)
+		controller := snake.NewController(game, view)
+		path := filepath.Join(t.TempDir(), "save.json")
+		controller.SetSaveFile(path)
+		controller.SetWinCondition(snake.AllOf(snake.Eat(1), snake.Eat(2)))
+
+		go controller.Start(time.Microsecond)
+		startPlaying(t, view)
+		view.SaveC <- struct{}{}
+
+		<-game.PauseC
+		got := view.GetMenu(t)
+		if len(got.Text) != 1 || got.Text[0] != snake.ErrUnsavableGoal.Error() {
+			t.Errorf("got menu text %q, want %q", got.Text, snake.ErrUnsavableGoal)
+		}
+		snake.AssertError(t, controller.Err(), snake.ErrUnsavableGoal)
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("got error %v checking the save file, want it not written", err)
+		}
+	})
+}